@@ -1,26 +1,31 @@
 package main
 
 import (
+	"context"
 	"log"
+	"time"
+	_ "time/tzdata" // Session timezones must resolve even on images without zoneinfo
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/swagger"
 	"github.com/isd-sgcu/cutu2025-backend/config"
 	_ "github.com/isd-sgcu/cutu2025-backend/docs"
+	"github.com/isd-sgcu/cutu2025-backend/domain"
 	"github.com/isd-sgcu/cutu2025-backend/infrastructure"
 	"github.com/isd-sgcu/cutu2025-backend/middleware"
 	"github.com/isd-sgcu/cutu2025-backend/repository"
 	"github.com/isd-sgcu/cutu2025-backend/routes"
 	"github.com/isd-sgcu/cutu2025-backend/usecase"
+	"github.com/isd-sgcu/cutu2025-backend/utils"
 )
 
 func main() {
 	// Load configuration
 	cfg := config.LoadConfig()
 
-	// Initialize Fiber app
-	app := fiber.New()
+	// Initialize Fiber app; errors returned by handlers are rendered centrally
+	app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
 
 	// Add middleware
 	app.Use(middleware.RequestLoggerMiddleware())
@@ -37,17 +42,87 @@ func main() {
 	// Connect to S3
 	s3 := infrastructure.ConnectToS3(cfg)
 
-	// Connect to Cache
+	// Fetch LINE signing keys
+	lineJWKS := infrastructure.ConnectToLineJWKS(cfg)
+	idTokenVerifier := utils.NewLineIDTokenVerifier(lineJWKS.Keyfunc, cfg.LineChannelID, cfg.LineIssuer)
+
+	// Load QR signing keys; the first key signs, the rest are still accepted while rotating
+	qrKeys, err := utils.ParseQRKeys(cfg.QRSigningKeys)
+	if err != nil {
+		log.Fatalf("Failed to load QR signing keys: %v", err)
+	}
+	qrSigner := utils.NewQRTokenSigner(qrKeys, cfg.QRTokenTTL)
+
+	// Load the key offline scanners verify attendee snapshots with
+	snapshotKey, err := utils.ParseSnapshotKey(cfg.SnapshotSigningKey)
+	if err != nil {
+		log.Fatalf("Failed to load snapshot signing key: %v", err)
+	}
+	snapshotSigner := utils.NewSnapshotSigner(snapshotKey)
+
+	// Days are counted in the event's timezone when no sessions are configured
+	eventTimezone, err := time.LoadLocation(cfg.EventTimezone)
+	if err != nil {
+		log.Fatalf("Failed to load event timezone: %v", err)
+	}
+
+	// Set up the pub/sub that carries check-ins to live dashboards. Redis is
+	// needed when several instances run, so every dashboard sees every scan.
+	var checkInEvents usecase.CheckInEventBrokerInterface
+	if cfg.PubSubBackend == "redis" {
+		checkInEvents = repository.NewRedisCheckInBroker(context.Background(), infrastructure.ConnectToRedis(cfg), cfg.PubSubChannel)
+	} else {
+		checkInEvents = utils.NewBroker[domain.CheckInEvent]()
+	}
 
 	// Initialize repositories
 	repo := repository.NewUserRepository(db)
 	storage := repository.NewStorageRepository(s3)
+	refreshTokenRepo := repository.NewRefreshTokenRepository(db)
+	qrNonceRepo := repository.NewQrNonceRepository(db)
+	checkInRepo := repository.NewCheckInRepository(db)
+	eventRepo := repository.NewEventRepository(db)
+	zoneRepo := repository.NewZoneRepository(db)
+	roleGrantRepo := repository.NewRoleGrantRepository(db)
+	invitationRepo := repository.NewInvitationRepository(db)
+	quotaRepo := repository.NewRegistrationQuotaRepository(db)
 
 	// Initialize use cases
-	userUsecase := usecase.NewUserUsecase(repo, storage)
+	authUsecase := usecase.NewAuthUsecase(refreshTokenRepo, repo, cfg.JWTSecret, cfg.AccessTokenTTL, cfg.RefreshTokenTTL)
+	userUsecase := usecase.NewUserUsecase(repo, storage, idTokenVerifier, authUsecase, qrSigner, roleGrantRepo, invitationRepo)
+	userUsecase.QRLogo = infrastructure.LoadQRLogo(cfg)
+	if cfg.RoleCacheTTL > 0 {
+		userUsecase.RoleCache = utils.NewTTLCache[string, domain.Role](cfg.RoleCacheTTL)
+	}
+
+	roleGrantUsecase := usecase.NewRoleGrantUsecase(roleGrantRepo, repo, userUsecase)
+	if err := roleGrantUsecase.Bootstrap(cfg.BootstrapAdminLineID); err != nil {
+		log.Fatalf("Failed to bootstrap admin: %v", err)
+	}
+
+	invitationUsecase := usecase.NewInvitationUsecase(invitationRepo)
+	quotaUsecase := usecase.NewRegistrationQuotaUsecase(quotaRepo)
+	eventUsecase := usecase.NewEventUsecase(eventRepo)
+	zoneUsecase := usecase.NewZoneUsecase(zoneRepo, repo)
+	checkInUsecase := usecase.NewCheckInUsecase(checkInRepo, repo, eventRepo, zoneRepo, qrSigner, qrNonceRepo)
+	checkInUsecase.Events = checkInEvents
+	checkInUsecase.Timezone = eventTimezone
+	go checkInUsecase.PurgeExpiredNonces(context.Background(), 10*time.Minute)
+	scannerUsecase := usecase.NewScannerUsecase(repo, eventRepo, zoneRepo, checkInRepo, checkInUsecase, snapshotSigner)
+	scannerUsecase.Events = checkInEvents
+	dashboardUsecase := usecase.NewDashboardUsecase(checkInRepo, checkInEvents)
 
 	// Register routes
 	routes.RegisterUserRoutes(app, userUsecase) // Register the user routes
+	routes.RegisterAuthRoutes(app, authUsecase)
+	routes.RegisterRoleGrantRoutes(app, roleGrantUsecase, userUsecase)
+	routes.RegisterInvitationRoutes(app, invitationUsecase, userUsecase)
+	routes.RegisterRegistrationQuotaRoutes(app, quotaUsecase, userUsecase)
+	routes.RegisterCheckInRoutes(app, checkInUsecase, userUsecase)
+	routes.RegisterEventRoutes(app, eventUsecase, userUsecase)
+	routes.RegisterZoneRoutes(app, zoneUsecase, userUsecase)
+	routes.RegisterScannerRoutes(app, scannerUsecase, userUsecase)
+	routes.RegisterDashboardRoutes(app, dashboardUsecase, authUsecase, userUsecase)
 
 	app.Get("/swagger/*", swagger.New(swagger.Config{
 		URL: "/swagger/doc.json", // URL to access the Swagger docs
@@ -2,24 +2,40 @@ package config
 
 import (
 	"log"
+	"time"
 
 	"github.com/isd-sgcu/cutu2025-backend/utils"
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	DBHost             string
-	DBPort             string
-	DBUser             string
-	DBPassword         string
-	DBName             string
-	AWSRegion          string
-	AWSAccessKeyID     string
-	AWSSecretAccessKey string
-	S3BucketName       string
-	RedisHost          string
-	RedisPort          string
-	RedisPassword      string
+	DBHost               string
+	DBPort               string
+	DBUser               string
+	DBPassword           string
+	DBName               string
+	AWSRegion            string
+	AWSAccessKeyID       string
+	AWSSecretAccessKey   string
+	S3BucketName         string
+	RedisHost            string
+	RedisPort            string
+	RedisPassword        string
+	LineChannelID        string
+	LineJWKSURL          string
+	LineIssuer           string
+	JWTSecret            string
+	AccessTokenTTL       time.Duration
+	RefreshTokenTTL      time.Duration
+	RoleCacheTTL         time.Duration
+	QRSigningKeys        string
+	QRTokenTTL           time.Duration
+	QRLogoPath           string
+	SnapshotSigningKey   string
+	PubSubBackend        string
+	PubSubChannel        string
+	BootstrapAdminLineID string
+	EventTimezone        string
 }
 
 // LoadConfig loads environment variables from .env and returns a Config struct
@@ -30,17 +46,32 @@ func LoadConfig() *Config {
 	}
 
 	return &Config{
-		DBHost:             utils.GetEnv("DB_HOST", "localhost"),
-		DBPort:             utils.GetEnv("DB_PORT", "5432"),
-		DBUser:             utils.GetEnv("DB_USER", "postgres"),
-		DBPassword:         utils.GetEnv("DB_PASSWORD", ""),
-		DBName:             utils.GetEnv("DB_NAME", "postgres"),
-		AWSRegion:          utils.GetEnv("AWS_REGION", "us-east-1"),
-		AWSAccessKeyID:     utils.GetEnv("AWS_ACCESS_KEY_ID", ""),
-		AWSSecretAccessKey: utils.GetEnv("AWS_SECRET_ACCESS_KEY", ""),
-		S3BucketName:       utils.GetEnv("S3_BUCKET_NAME", ""),
-		RedisHost:          utils.GetEnv("REDIS_HOST", "localhost"),
-		RedisPort:          utils.GetEnv("REDIS_PORT", "6379"),
-		RedisPassword:      utils.GetEnv("REDIS_PASSWORD", ""),
+		DBHost:               utils.GetEnv("DB_HOST", "localhost"),
+		DBPort:               utils.GetEnv("DB_PORT", "5432"),
+		DBUser:               utils.GetEnv("DB_USER", "postgres"),
+		DBPassword:           utils.GetEnv("DB_PASSWORD", ""),
+		DBName:               utils.GetEnv("DB_NAME", "postgres"),
+		AWSRegion:            utils.GetEnv("AWS_REGION", "us-east-1"),
+		AWSAccessKeyID:       utils.GetEnv("AWS_ACCESS_KEY_ID", ""),
+		AWSSecretAccessKey:   utils.GetEnv("AWS_SECRET_ACCESS_KEY", ""),
+		S3BucketName:         utils.GetEnv("S3_BUCKET_NAME", ""),
+		RedisHost:            utils.GetEnv("REDIS_HOST", "localhost"),
+		RedisPort:            utils.GetEnv("REDIS_PORT", "6379"),
+		RedisPassword:        utils.GetEnv("REDIS_PASSWORD", ""),
+		LineChannelID:        utils.GetEnv("LINE_CHANNEL_ID", ""),
+		LineJWKSURL:          utils.GetEnv("LINE_JWKS_URL", "https://api.line.me/oauth2/v2.1/certs"),
+		LineIssuer:           utils.GetEnv("LINE_ISSUER", "https://access.line.me"),
+		JWTSecret:            utils.GetEnv("SECRET_JWT_KEY", ""),
+		AccessTokenTTL:       utils.GetEnvDuration("ACCESS_TOKEN_TTL", 15*time.Minute),
+		RefreshTokenTTL:      utils.GetEnvDuration("REFRESH_TOKEN_TTL", 30*24*time.Hour),
+		RoleCacheTTL:         utils.GetEnvDuration("ROLE_CACHE_TTL", 0),
+		QRSigningKeys:        utils.GetEnv("QR_SIGNING_KEYS", ""),
+		QRTokenTTL:           utils.GetEnvDuration("QR_TOKEN_TTL", time.Minute),
+		QRLogoPath:           utils.GetEnv("QR_LOGO_PATH", ""),
+		SnapshotSigningKey:   utils.GetEnv("SNAPSHOT_SIGNING_KEY", ""),
+		PubSubBackend:        utils.GetEnv("PUBSUB_BACKEND", "memory"),
+		PubSubChannel:        utils.GetEnv("PUBSUB_CHANNEL", "cutu2025:checkins"),
+		BootstrapAdminLineID: utils.GetEnv("BOOTSTRAP_ADMIN_LINE_ID", ""),
+		EventTimezone:        utils.GetEnv("EVENT_TIMEZONE", "Asia/Bangkok"),
 	}
 }
@@ -15,72 +15,76 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/users": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Retrieve a list of all users with optional filtering",
-                "produces": [
+        "/api/auth/logout": {
+            "post": {
+                "description": "Revoke a refresh token and every token rotated from the same sign-in",
+                "consumes": [
                     "application/json"
                 ],
-                "summary": "Get all users",
+                "summary": "Logout",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Filter by name",
-                        "name": "name",
-                        "in": "query"
+                        "description": "Refresh Token",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.RefreshTokenRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Invalid input",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.User"
-                            }
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid refresh token",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to fetch users",
+                        "description": "Failed to logout",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     }
                 }
-            },
-            "patch": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Update a user by its ID",
+            }
+        },
+        "/api/auth/refresh": {
+            "post": {
+                "description": "Exchange a refresh token for a new access and refresh token. Each refresh token can only be used once.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Update Account Info",
+                "summary": "Refresh tokens",
                 "parameters": [
                     {
-                        "description": "User data",
-                        "name": "user",
+                        "description": "Refresh Token",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.User"
+                            "$ref": "#/definitions/domain.RefreshTokenRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.TokenResponse"
+                        }
                     },
                     "400": {
                         "description": "Invalid input",
@@ -89,25 +93,13 @@ const docTemplate = `{
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "User not found",
+                        "description": "Invalid or expired refresh token",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to update user role",
+                        "description": "Failed to refresh token",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -115,39 +107,140 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/users/addstaff/{phone}": {
-            "patch": {
+        "/api/checkins": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Add Staff By phone number",
+                "description": "List scan attempts, newest first, filtered by user, scanner, gate, zone, direction, source, result and time range",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Add Staff",
+                "summary": "List check-ins",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User Phone",
-                        "name": "phone",
-                        "in": "path",
-                        "required": true
+                        "description": "Filter by user ID",
+                        "name": "userId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by session ID",
+                        "name": "sessionId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by the staff member who scanned",
+                        "name": "scannerId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by gate",
+                        "name": "gate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by zone ID",
+                        "name": "zoneId",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "entry",
+                            "exit"
+                        ],
+                        "type": "string",
+                        "description": "Filter by direction",
+                        "name": "direction",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "online",
+                            "offline",
+                            "manual"
+                        ],
+                        "type": "string",
+                        "description": "Filter by source",
+                        "name": "source",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "accepted",
+                            "duplicate",
+                            "rejected",
+                            "conflict",
+                            "voided"
+                        ],
+                        "type": "string",
+                        "description": "Filter by result",
+                        "name": "result",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Scanned at or after (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Scanned before (RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Maximum number of results (1-1000)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of results to skip",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.CheckIn"
+                            }
+                        }
                     },
                     "400": {
-                        "description": "User is already a staff",
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to add staff",
+                        "description": "Failed to fetch check-ins",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -155,32 +248,43 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/users/image/{id}": {
+        "/api/checkins/lookup": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve a image URL for a user",
+                "description": "Find an attendee by UID (e.g. AB2345678C) or phone when their QR code won't scan. Staff should compare the photo (imageUrl) with the attendee before checking them in manually.",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Get Image URL",
+                "summary": "Look up an attendee",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Attendee UID",
+                        "name": "uid",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attendee phone number",
+                        "name": "phone",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.ImageResponse"
+                            "$ref": "#/definitions/domain.GateAttendee"
+                        }
+                    },
+                    "400": {
+                        "description": "Either uid or phone is required, or the UID has a typo",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "404": {
@@ -198,83 +302,132 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/users/qr/{id}": {
-            "get": {
+        "/api/checkins/manual": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve a QR code URL for a user",
+                "description": "Check an attendee found with the lookup endpoint in or out without scanning their QR code. The same entry rules as scanning apply, and the check-in is logged with the manual source.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Get QR code URL",
+                "summary": "Check in manually",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Attendee and where they are being checked in",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.ManualCheckInRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.QrResponse"
+                            "$ref": "#/definitions/domain.ScanResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "User not found",
+                        "description": "User or gate not found, or no gate was given while a zone restricts who may enter",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "User has already entered or is not inside, no session is open for entry, or the zone is full",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ScanResult"
+                        }
+                    },
+                    "422": {
+                        "description": "User is not permitted in this zone or is on the waitlist",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ScanResult"
+                        }
+                    },
                     "500": {
-                        "description": "Failed to fetch user",
+                        "description": "Failed to check in",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/api/checkins/{id}/void": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve a user by its ID",
+                "description": "Revert a mistaken accepted check-in, e.g. when the wrong person was scanned. The user's last entry time and presence are restored from their remaining check-ins so they can be scanned again. Who voided it, when and why are recorded on the check-in.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Scan QR code",
+                "summary": "Void a check-in",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "Check-in ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Why the check-in is being voided",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.VoidCheckInRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.User"
+                            "$ref": "#/definitions/domain.CheckIn"
                         }
                     },
                     "400": {
-                        "description": "User has already entered",
+                        "description": "A reason is required to void a check-in",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Check-in not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Check-in cannot be voided",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to fetch User",
+                        "description": "Failed to void check-in",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -282,146 +435,59 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/users/register": {
-            "post": {
-                "description": "Register a new user in the system",
-                "consumes": [
-                    "multipart/form-data"
+        "/api/dashboard/stats": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Accepted entries over the last hour: total, per minute, per gate and per status",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Register a new user",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "User Name",
-                        "name": "name",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "User Email",
-                        "name": "email",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "User Phone",
-                        "name": "phone",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "User University",
-                        "name": "university",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Jersey Size",
-                        "name": "sizeJersey",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Food Limitation",
-                        "name": "foodLimitation",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Invitation Code",
-                        "name": "invitationCode",
-                        "in": "formData"
-                    },
-                    {
-                        "enum": [
-                            "chula_student",
-                            "alumni",
-                            "general_public",
-                            "general_student"
-                        ],
-                        "type": "string",
-                        "description": "User Status",
-                        "name": "status",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "file",
-                        "description": "User Image",
-                        "name": "image",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "User Age",
-                        "name": "age",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Chronic Disease",
-                        "name": "chronicDisease",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Drug Allergy",
-                        "name": "drugAllergy",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Graduated Year",
-                        "name": "graduatedYear",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Faculty",
-                        "name": "faculty",
-                        "in": "formData"
+                "summary": "Get check-in aggregates",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.DashboardStats"
+                        }
                     },
+                    "500": {
+                        "description": "Failed to fetch stats",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/dashboard/stream": {
+            "get": {
+                "security": [
                     {
-                        "type": "boolean",
-                        "description": "Is Acrophobia",
-                        "name": "isAcroPhobia",
-                        "in": "formData",
-                        "required": true
-                    },
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Server-Sent Events stream. A \"checkin\" event (domain.CheckInEvent) is sent for every scan attempt, and a \"stats\" event (domain.DashboardStats) every 5 seconds. Authenticate with the Authorization header, or with a stream token in the token query parameter when using EventSource.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "summary": "Stream check-ins",
+                "parameters": [
                     {
-                        "enum": [
-                            "studying",
-                            "graduated"
-                        ],
                         "type": "string",
-                        "description": "Education",
-                        "name": "education",
-                        "in": "formData"
+                        "description": "Stream token from /api/dashboard/stream-token",
+                        "name": "token",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/domain.TokenResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid input",
+                    "200": {
+                        "description": "Event stream",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "type": "string"
                         }
                     },
                     "401": {
@@ -430,8 +496,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Failed to create user",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -439,50 +505,23 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/users/role/{id}": {
-            "patch": {
+        "/api/dashboard/stream-token": {
+            "post": {
                 "security": [
-                    {
-                        "BearerAuth": []
-                    },
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update a user by its ID",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Short-lived token for opening the check-in stream with EventSource, which cannot send an Authorization header. Pass it as the token query parameter; it is only checked when the stream is opened, so fetch a new one before reconnecting.",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Update user role by ID",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "User Role",
-                        "name": "role",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
-                    }
-                ],
+                "summary": "Get a stream token",
                 "responses": {
-                    "204": {
-                        "description": "No Content"
-                    },
-                    "400": {
-                        "description": "Invalid input",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "$ref": "#/definitions/domain.StreamTokenResponse"
                         }
                     },
                     "401": {
@@ -497,48 +536,79 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "User not found",
+                    "500": {
+                        "description": "Failed to issue stream token",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
+                    }
+                }
+            }
+        },
+        "/api/events": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve all events with their sessions",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get all events",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Event"
+                            }
+                        }
                     },
                     "500": {
-                        "description": "Failed to update user role",
+                        "description": "Failed to fetch events",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/api/users/signin": {
+            },
             "post": {
-                "description": "SignIn",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create an event; sessions are added separately",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "SignIn",
+                "summary": "Create an event",
                 "parameters": [
                     {
-                        "description": "User ID",
-                        "name": "id",
+                        "description": "Event data",
+                        "name": "event",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/domain.Event"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/domain.TokenResponse"
+                            "$ref": "#/definitions/domain.Event"
                         }
                     },
                     "400": {
-                        "description": "Invalid input",
+                        "description": "Invalid event",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -549,8 +619,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
                     "500": {
-                        "description": "Failed to signin",
+                        "description": "Failed to create event",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -558,22 +634,22 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/users/{id}": {
+        "/api/events/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve a user by its ID",
+                "description": "Retrieve an event with its sessions",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Get user by ID",
+                "summary": "Get event by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "Event ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -583,17 +659,17 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.User"
+                            "$ref": "#/definitions/domain.Event"
                         }
                     },
                     "404": {
-                        "description": "User not found",
+                        "description": "Event not found",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to fetch user",
+                        "description": "Failed to fetch event",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -606,15 +682,12 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a user by its ID",
-                "produces": [
-                    "application/json"
-                ],
-                "summary": "Delete user by ID",
+                "description": "Delete an event and its sessions",
+                "summary": "Delete event by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "Event ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -624,156 +697,3473 @@ const docTemplate = `{
                     "204": {
                         "description": "No Content"
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
                     "404": {
-                        "description": "User not found",
+                        "description": "Event not found",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to delete user",
+                        "description": "Failed to delete event",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/api/events/{id}/sessions": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update a user by its ID",
+                "description": "Add a session with its entry window and policy. Sessions may not overlap.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Update user by ID",
+                "summary": "Add a session to an event",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "Event ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "User data",
-                        "name": "user",
+                        "description": "Session data",
+                        "name": "session",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.User"
+                            "$ref": "#/definitions/domain.CreateSessionRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
-                    },
-                    "400": {
-                        "description": "Invalid input",
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Session"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid session",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Event not found",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "409": {
+                        "description": "Session overlaps another session",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to create session",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/gates": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get all gates",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Gate"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to fetch gates",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Register a scanning point and the zone it admits into. The gate ID is what scanners send as the gate query parameter.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Register a gate",
+                "parameters": [
+                    {
+                        "description": "Gate data",
+                        "name": "gate",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.Gate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Gate"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid gate",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "User not found",
+                        "description": "Zone not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to create gate",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/gates/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Delete gate by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Gate ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Gate not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to delete gate",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/gates/{id}/staff": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get staff assigned to a gate",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Gate ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.GateAssignment"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Gate not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to fetch staff",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/gates/{id}/staff/{staffId}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Place a staff member at a gate. Their scans are then made at that gate and checked against its zone. Replaces any earlier assignment.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Assign staff to a gate",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Gate ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Staff user ID",
+                        "name": "staffId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.GateAssignment"
+                        }
+                    },
+                    "400": {
+                        "description": "User is not staff",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Gate or user not found",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
-                    },
-                    "500": {
-                        "description": "Failed to update user",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                    },
+                    "500": {
+                        "description": "Failed to assign staff",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Unassign staff from a gate",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Gate ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Staff user ID",
+                        "name": "staffId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Staff is not assigned to this gate",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to unassign staff",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/invitations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List invitation codes with how often each has been used",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get invitation codes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only codes from this batch",
+                        "name": "batchId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Invitation"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to fetch invitations",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Generate a batch of codes that can each be redeemed maxUses times before expiresAt. Users who register with a code are given its status and ticket type, if set; zones admit ticket types through their allowlists.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Generate invitation codes",
+                "parameters": [
+                    {
+                        "description": "Batch data",
+                        "name": "batch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CreateInvitationsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Invitation"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid invitation",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to create invitations",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/invitations/redemptions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List which users redeemed which code. Leave out the code to list redemptions of every code, optionally only from one batch.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get invitation redemptions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only codes from this batch",
+                        "name": "batchId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.RedemptionDetail"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Invitation not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to fetch redemptions",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/invitations/{code}/redemptions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List which users redeemed which code. Leave out the code to list redemptions of every code, optionally only from one batch.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get invitation redemptions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Invitation code",
+                        "name": "code",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only codes from this batch",
+                        "name": "batchId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.RedemptionDetail"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Invitation not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to fetch redemptions",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/invitations/{code}/revoke": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stop a code from being redeemed. Users who already redeemed it keep what it granted.",
+                "summary": "Revoke invitation code",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Invitation code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Invitation not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Invitation has already been revoked",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to revoke invitation",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/occupancy": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Number of attendees currently inside the venue, overall and per zone",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get live occupancy",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Occupancy"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to fetch occupancy",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/quotas": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List each status's quota with how many users are confirmed and waitlisted. Statuses with users but no quota are listed with a null capacity.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get registration quotas",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.QuotaUsage"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to fetch quotas",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/quotas/{status}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cap how many users of a status get a place. Users who register once it is full are waitlisted. Raising the capacity promotes waitlisted users, earliest first; lowering it does not remove anyone.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Set registration quota",
+                "parameters": [
+                    {
+                        "enum": [
+                            "chula_student",
+                            "alumni",
+                            "general_public",
+                            "general_student"
+                        ],
+                        "type": "string",
+                        "description": "User status",
+                        "name": "status",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Quota data",
+                        "name": "quota",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.RegistrationQuota"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.RegistrationQuota"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid quota",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to set quota",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Make a status unlimited again. Everyone on its waitlist is confirmed.",
+                "summary": "Delete registration quota",
+                "parameters": [
+                    {
+                        "enum": [
+                            "chula_student",
+                            "alumni",
+                            "general_public",
+                            "general_student"
+                        ],
+                        "type": "string",
+                        "description": "User status",
+                        "name": "status",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Quota not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to delete quota",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/roles/grants": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get all role grants",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.RoleGrant"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to fetch role grants",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Give a role to whoever registers with a LIFF ID. A user who has already registered with it is given the role straight away. Granting again to the same LIFF ID changes the role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Grant a role",
+                "parameters": [
+                    {
+                        "description": "Grant data",
+                        "name": "grant",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.RoleGrant"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.RoleGrant"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid role grant",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to grant role",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/roles/grants/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stop granting the role to new registrations. Users who already hold the role keep it; change it with the update role endpoint.",
+                "summary": "Delete role grant by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role grant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Role grant not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to delete role grant",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/scanner/key": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Public key devices use to verify attendee snapshots",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get snapshot signing key",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SnapshotKey"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/scanner/snapshot": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Signed list of attendees the scanner may admit for a session, so it can keep scanning offline. Pass the cursor from the previous snapshot to fetch only what has changed since; if the session or zone rules have changed, a full snapshot is returned instead.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Download attendee snapshot",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "sessionId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from the previous snapshot",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SignedSnapshot"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid cursor",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Session not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to build snapshot",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/scanner/sync": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Reconcile scans a device made while offline. Scans the device admitted must carry the QR token it scanned, which must have been valid at scannedAt. Each scan is decided as if it had been made online at its scannedAt. Scans the device let through that the server would have refused are recorded as conflicts. Uploading the same scan ID again returns its recorded outcome.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Upload offline scans",
+                "parameters": [
+                    {
+                        "description": "Offline scans (at most 500)",
+                        "name": "scans",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.SyncRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SyncResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to sync scans",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/sessions/active": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the session gates are currently admitting to",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get the active session",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Session"
+                        }
+                    },
+                    "409": {
+                        "description": "No session is open for entry",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to fetch session",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/sessions/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Delete session by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Session not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to delete session",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update a session's name, window or entry policy. Fields left out are not changed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "summary": "Update session by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "session",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.UpdateSessionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Invalid session",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Session not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Session overlaps another session",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update session",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List a page of users, filtered and sorted. Filters combine; the total counts every user matching them.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "List users",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Part of a name, phone number, UID or email",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "chula_student",
+                            "alumni",
+                            "general_public",
+                            "general_student"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "member",
+                            "staff",
+                            "admin"
+                        ],
+                        "type": "string",
+                        "description": "Filter by role",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "confirmed",
+                            "waitlisted"
+                        ],
+                        "type": "string",
+                        "description": "Filter by registration",
+                        "name": "registration",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by university, ignoring case",
+                        "name": "university",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by faculty, ignoring case",
+                        "name": "faculty",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "XS",
+                            "S",
+                            "M",
+                            "L",
+                            "XL",
+                            "2XL",
+                            "3XL"
+                        ],
+                        "type": "string",
+                        "description": "Filter by jersey size",
+                        "name": "sizeJersey",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Whether the user has entered the event",
+                        "name": "checkedIn",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Registered at or after (RFC 3339)",
+                        "name": "registeredFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Registered before (RFC 3339)",
+                        "name": "registeredTo",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "registeredAt",
+                            "-registeredAt",
+                            "updatedAt",
+                            "-updatedAt",
+                            "lastEntered",
+                            "-lastEntered",
+                            "name",
+                            "-name",
+                            "uid",
+                            "-uid"
+                        ],
+                        "type": "string",
+                        "default": "-registeredAt",
+                        "description": "Field to sort by, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Maximum number of results (1-1000)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of results to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.UserPage"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to fetch users",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete your own account. Your place at the event goes to the first person on the waitlist for your status.",
+                "summary": "Cancel my registration",
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to cancel registration",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change your own profile. Only the fields sent are changed; your role, status, ticket type and UID cannot be changed here.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Update Account Info",
+                "parameters": [
+                    {
+                        "description": "Fields to change; send \\",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.UpdateProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Phone number is already registered",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update user role",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/addstaff/{phone}": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add Staff By phone number",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Add Staff",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User Phone, e.g. 0812345678 or +66812345678",
+                        "name": "phone",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Invalid phone number",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "User is already a staff",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to add staff",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Download the users matching the filters as a CSV file or Excel workbook, in registration order. CSV files start with a UTF-8 byte order mark so Excel shows Thai text correctly. CSV is streamed as it is read from the database; an Excel workbook is assembled on the server (on disk past 16 MB) and only starts downloading once every row has been read, so prefer CSV for very large exports.",
+                "produces": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "summary": "Export users",
+                "parameters": [
+                    {
+                        "enum": [
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "default": "csv",
+                        "description": "File format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated columns to include, in order, e.g. name,phone,sizeJersey; every column by default",
+                        "name": "columns",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Part of a name, phone number, UID or email",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "chula_student",
+                            "alumni",
+                            "general_public",
+                            "general_student"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "member",
+                            "staff",
+                            "admin"
+                        ],
+                        "type": "string",
+                        "description": "Filter by role",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "confirmed",
+                            "waitlisted"
+                        ],
+                        "type": "string",
+                        "description": "Filter by registration",
+                        "name": "registration",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by university, ignoring case",
+                        "name": "university",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by faculty, ignoring case",
+                        "name": "faculty",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "XS",
+                            "S",
+                            "M",
+                            "L",
+                            "XL",
+                            "2XL",
+                            "3XL"
+                        ],
+                        "type": "string",
+                        "description": "Filter by jersey size",
+                        "name": "sizeJersey",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Whether the user has entered the event",
+                        "name": "checkedIn",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Registered at or after (RFC 3339)",
+                        "name": "registeredFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Registered before (RFC 3339)",
+                        "name": "registeredTo",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to export users",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/image/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a image URL for a user",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get Image URL",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ImageResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to fetch user",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/qr/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Issue a short-lived signed QR code to the attendee it identifies; no one else may mint a code for them, so staff admit attendees without one through manual check-in, which is audited. The app should fetch a new one every refreshAfter seconds.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get QR code URL",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.QrResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to fetch user",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/qr/{id}/image": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Render the caller's current QR code as PNG or SVG. Only the attendee the code identifies may fetch it. Responses carry an ETag that stays the same until a new code is issued.",
+                "produces": [
+                    "image/png",
+                    "image/svg+xml"
+                ],
+                "summary": "Get QR code image",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "png",
+                            "svg"
+                        ],
+                        "type": "string",
+                        "default": "png",
+                        "description": "Image format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 512,
+                        "description": "Width and height in pixels (64-2048)",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "low",
+                            "medium",
+                            "high",
+                            "highest"
+                        ],
+                        "type": "string",
+                        "default": "medium",
+                        "description": "Error-correction level",
+                        "name": "level",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Draw the logo in the centre",
+                        "name": "logo",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "400": {
+                        "description": "Invalid QR image options",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to render QR code",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/qr/{token}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Check the user identified by a signed QR token in or out. Each token can only be scanned once and expires shortly after it is issued. Every attempt is recorded in the check-in log.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Scan QR code",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "QR Token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Gate the scan was made at; ignored for staff assigned to a gate",
+                        "name": "gate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Scanning device",
+                        "name": "deviceId",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "entry",
+                            "exit"
+                        ],
+                        "type": "string",
+                        "default": "entry",
+                        "description": "Whether the user is entering or leaving",
+                        "name": "direction",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ScanResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid direction",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Gate not found, or no gate was given while a zone restricts who may enter",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "QR code has already been scanned, user has already entered or is not inside, no session is open for entry, or the zone is full",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ScanResult"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid or expired QR code, or the user is not permitted in this zone or is on the waitlist",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ScanResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to scan QR",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/register": {
+            "post": {
+                "description": "Register a new user in the system",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Register a new user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "LINE ID Token",
+                        "name": "idToken",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User Name",
+                        "name": "name",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User Email",
+                        "name": "email",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "User Phone",
+                        "name": "phone",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User University",
+                        "name": "university",
+                        "in": "formData"
+                    },
+                    {
+                        "enum": [
+                            "XS",
+                            "S",
+                            "M",
+                            "L",
+                            "XL",
+                            "2XL",
+                            "3XL"
+                        ],
+                        "type": "string",
+                        "description": "Jersey Size",
+                        "name": "sizeJersey",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Food Limitation",
+                        "name": "foodLimitation",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Invitation code, which is consumed and may grant a status or ticket type",
+                        "name": "invitationCode",
+                        "in": "formData"
+                    },
+                    {
+                        "enum": [
+                            "chula_student",
+                            "alumni",
+                            "general_public",
+                            "general_student"
+                        ],
+                        "type": "string",
+                        "description": "User Status",
+                        "name": "status",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "User Image",
+                        "name": "image",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "User Age",
+                        "name": "age",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Chronic Disease",
+                        "name": "chronicDisease",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Drug Allergy",
+                        "name": "drugAllergy",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Graduated year, required for alumni",
+                        "name": "graduatedYear",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Faculty, required for chula_student",
+                        "name": "faculty",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Is Acrophobia",
+                        "name": "isAcroPhobia",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "studying",
+                            "graduated"
+                        ],
+                        "type": "string",
+                        "description": "Education",
+                        "name": "education",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.TokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input, with every invalid field listed, or invalid invitation code",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Phone number is already registered",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to create user",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/role/{id}": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change a user's role. The user is signed out so their sessions pick up the new role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Update user role by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "User Role",
+                        "name": "role",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update user role",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/signin": {
+            "post": {
+                "description": "SignIn with a LINE ID token obtained from LIFF",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "SignIn",
+                "parameters": [
+                    {
+                        "description": "LINE ID Token",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.SignInRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.TokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to signin",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a user by its ID",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get user by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.User"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to fetch user",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a user by its ID. If they had a place at the event it goes to the first person on the waitlist for their status.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Delete user by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to delete user",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change a user's profile, status or ticket type. Only the fields sent are changed. Roles are changed with the update role and add staff endpoints.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Update user by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change; send \\",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.AdminUpdateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Phone number is already registered",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update user",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/waitlist/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "See whether you have a place at the event and, if you are waitlisted, how many people are ahead of you. Position 1 is next in line.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get my waitlist position",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.WaitlistPosition"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to fetch waitlist position",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/zones": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get all zones",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Zone"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to fetch zones",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create an area of the venue. A capacity of 0 means unlimited. Entry can be restricted to the listed statuses, roles and ticket types; an empty list allows everyone.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Create a zone",
+                "parameters": [
+                    {
+                        "description": "Zone data",
+                        "name": "zone",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CreateZoneRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Zone"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid zone",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to create zone",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/zones/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a zone. Zones that gates still admit into cannot be deleted.",
+                "summary": "Delete zone by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Zone ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Zone not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Zone still has gates",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to delete zone",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Rename a zone or change its capacity (0 means unlimited) and allow lists. Fields left out are not changed; send [] to clear an allow list.",
+                "consumes": [
+                    "application/json"
+                ],
+                "summary": "Update zone by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Zone ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "zone",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.UpdateZoneRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Invalid zone",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Zone not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update zone",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "domain.AdminUpdateUserRequest": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "type": "string"
+                },
+                "chronicDisease": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "drugAllergy": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "education": {
+                    "$ref": "#/definitions/domain.Education"
+                },
+                "email": {
+                    "type": "string",
+                    "maxLength": 254
+                },
+                "faculty": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "foodLimitation": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "graduatedYear": {
+                    "type": "string"
+                },
+                "isAcroPhobia": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "sizeJersey": {
+                    "$ref": "#/definitions/domain.JerseySize"
+                },
+                "status": {
+                    "enum": [
+                        "chula_student",
+                        "alumni",
+                        "general_public",
+                        "general_student"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.Status"
+                        }
+                    ]
+                },
+                "ticketType": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "university": {
+                    "type": "string",
+                    "maxLength": 200
+                }
+            }
+        },
+        "domain.CheckIn": {
+            "type": "object",
+            "properties": {
+                "deviceId": {
+                    "type": "string"
+                },
+                "direction": {
+                    "$ref": "#/definitions/domain.CheckInDirection"
+                },
+                "gate": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "result": {
+                    "$ref": "#/definitions/domain.CheckInResult"
+                },
+                "scannedAt": {
+                    "type": "string"
+                },
+                "scannerId": {
+                    "type": "string"
+                },
+                "sessionId": {
+                    "type": "string"
+                },
+                "source": {
+                    "$ref": "#/definitions/domain.CheckInSource"
+                },
+                "userId": {
+                    "description": "Empty if the QR code could not be resolved to a user",
+                    "type": "string"
+                },
+                "voidReason": {
+                    "type": "string"
+                },
+                "voidedAt": {
+                    "type": "string"
+                },
+                "voidedBy": {
+                    "type": "string"
+                },
+                "zoneId": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.CheckInDirection": {
+            "type": "string",
+            "enum": [
+                "entry",
+                "exit"
+            ],
+            "x-enum-varnames": [
+                "DirectionEntry",
+                "DirectionExit"
+            ]
+        },
+        "domain.CheckInResult": {
+            "type": "string",
+            "enum": [
+                "accepted",
+                "duplicate",
+                "rejected",
+                "conflict",
+                "voided"
+            ],
+            "x-enum-comments": {
+                "CheckInConflict": "Admitted offline, but the server would not have let them in",
+                "CheckInVoided": "Accepted, then reverted by staff"
+            },
+            "x-enum-varnames": [
+                "CheckInAccepted",
+                "CheckInDuplicate",
+                "CheckInRejected",
+                "CheckInConflict",
+                "CheckInVoided"
+            ]
+        },
+        "domain.CheckInSource": {
+            "type": "string",
+            "enum": [
+                "online",
+                "offline",
+                "manual"
+            ],
+            "x-enum-comments": {
+                "SourceManual": "Looked up by staff instead of scanned"
+            },
+            "x-enum-varnames": [
+                "SourceOnline",
+                "SourceOffline",
+                "SourceManual"
+            ]
+        },
+        "domain.CreateInvitationsRequest": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "maximum": 1000,
+                    "minimum": 1
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "label": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "maxUses": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "status": {
+                    "enum": [
+                        "chula_student",
+                        "alumni",
+                        "general_public",
+                        "general_student"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.Status"
+                        }
+                    ]
+                },
+                "ticketType": {
+                    "type": "string",
+                    "maxLength": 50
+                }
+            }
+        },
+        "domain.CreateSessionRequest": {
+            "type": "object",
+            "required": [
+                "endsAt",
+                "entryPolicy",
+                "name",
+                "startsAt"
+            ],
+            "properties": {
+                "endsAt": {
+                    "type": "string"
+                },
+                "entryPolicy": {
+                    "enum": [
+                        "single",
+                        "reentry",
+                        "paired"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.EntryPolicy"
+                        }
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 200
+                },
+                "startsAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.CreateZoneRequest": {
+            "type": "object",
+            "required": [
+                "allowedTicketTypes",
+                "name"
+            ],
+            "properties": {
+                "allowedRoles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Role"
+                    }
+                },
+                "allowedStatuses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Status"
+                    }
+                },
+                "allowedTicketTypes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "capacity": {
+                    "description": "0 means unlimited",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
+        "domain.DashboardStats": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "perGate": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "perMinute": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.MinuteCount"
+                    }
+                },
+                "perStatus": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.Education": {
+            "type": "string",
+            "enum": [
+                "studying",
+                "graduated"
+            ],
+            "x-enum-varnames": [
+                "EducationStudying",
+                "EducationGraduated"
+            ]
+        },
+        "domain.EntryPolicy": {
+            "type": "string",
+            "enum": [
+                "single",
+                "reentry",
+                "paired"
+            ],
+            "x-enum-comments": {
+                "EntryPaired": "Must be scanned out before entering again",
+                "EntryReentry": "Any number of entries",
+                "EntrySingle": "One accepted entry per session"
+            },
+            "x-enum-varnames": [
+                "EntrySingle",
+                "EntryReentry",
+                "EntryPaired"
+            ]
+        },
+        "domain.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Machine-readable, e.g. user_not_found",
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "fields": {
+                    "description": "Every invalid field, for validation errors",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.FieldError"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.Event": {
+            "type": "object",
+            "required": [
+                "name",
+                "timezone"
+            ],
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 200
+                },
+                "sessions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Session"
+                    }
+                },
+                "timezone": {
+                    "description": "IANA name, e.g. Asia/Bangkok; session times are shown in it",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "description": "Path of the field in the request, e.g. scans[0].id",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Human readable reason",
+                    "type": "string"
+                },
+                "rule": {
+                    "description": "The rule it broke, e.g. required or oneof",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.Gate": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "id": {
+                    "description": "Short code sent by scanners, e.g. north-1",
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "zoneId": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.GateAssignment": {
+            "type": "object",
+            "properties": {
+                "assignedAt": {
+                    "type": "string"
+                },
+                "gateId": {
+                    "type": "string"
+                },
+                "staffId": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.GateAttendee": {
+            "type": "object",
+            "properties": {
+                "hasMedicalNote": {
+                    "description": "Chronic disease or drug allergy on file",
+                    "type": "boolean"
+                },
+                "imageUrl": {
+                    "type": "string"
+                },
+                "isAcrophobic": {
+                    "type": "boolean"
+                },
+                "lastEntered": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/domain.Status"
+                },
+                "ticketType": {
+                    "type": "string"
+                },
+                "uid": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ImageResponse": {
+            "type": "object",
+            "properties": {
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.Invitation": {
+            "type": "object",
+            "properties": {
+                "batchId": {
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "label": {
+                    "description": "What the batch is for, e.g. \"Alumni association\"",
+                    "type": "string"
+                },
+                "maxUses": {
+                    "type": "integer"
+                },
+                "revokedAt": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status given to users who redeem the code, if any",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.Status"
+                        }
+                    ]
+                },
+                "ticketType": {
+                    "description": "Ticket type given to users who redeem the code, which zones can allow",
+                    "type": "string"
+                },
+                "uses": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.JerseySize": {
+            "type": "string",
+            "enum": [
+                "XS",
+                "S",
+                "M",
+                "L",
+                "XL",
+                "2XL",
+                "3XL"
+            ],
+            "x-enum-varnames": [
+                "JerseyXS",
+                "JerseyS",
+                "JerseyM",
+                "JerseyL",
+                "JerseyXL",
+                "Jersey2XL",
+                "Jersey3XL"
+            ]
+        },
+        "domain.ManualCheckInRequest": {
+            "type": "object",
+            "required": [
+                "userId"
+            ],
+            "properties": {
+                "deviceId": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "direction": {
+                    "enum": [
+                        "entry",
+                        "exit"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.CheckInDirection"
+                        }
+                    ]
+                },
+                "gate": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.MinuteCount": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "minute": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.Occupancy": {
+            "type": "object",
+            "properties": {
+                "total": {
+                    "type": "integer"
+                },
+                "zones": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ZoneOccupancy"
+                    }
+                }
+            }
+        },
+        "domain.OfflineScan": {
+            "type": "object",
+            "properties": {
+                "admitted": {
+                    "description": "Whether the device let the attendee through",
+                    "type": "boolean"
+                },
+                "direction": {
+                    "$ref": "#/definitions/domain.CheckInDirection"
+                },
+                "gate": {
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Generated by the device; re-uploading the same ID is a no-op",
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "Why the device turned the attendee away",
+                    "type": "string"
+                },
+                "scannedAt": {
+                    "type": "string"
+                },
+                "token": {
+                    "description": "The QR code scanned; required for scans the device admitted",
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.QrResponse": {
+            "type": "object",
+            "properties": {
+                "expiresAt": {
+                    "type": "string"
+                },
+                "qrUrl": {
+                    "type": "string"
+                },
+                "refreshAfter": {
+                    "description": "Seconds until the app should fetch a new code",
+                    "type": "integer"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.QuotaUsage": {
+            "type": "object",
+            "properties": {
+                "capacity": {
+                    "description": "Unlimited if null",
+                    "type": "integer"
+                },
+                "confirmed": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/domain.Status"
+                },
+                "waitlisted": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.RedemptionDetail": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "redeemedAt": {
+                    "type": "string"
+                },
+                "uid": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.RefreshTokenRequest": {
+            "type": "object",
+            "required": [
+                "refreshToken"
+            ],
+            "properties": {
+                "refreshToken": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.Registration": {
+            "type": "string",
+            "enum": [
+                "confirmed",
+                "waitlisted"
+            ],
+            "x-enum-varnames": [
+                "RegistrationConfirmed",
+                "RegistrationWaitlisted"
+            ]
+        },
+        "domain.RegistrationQuota": {
+            "type": "object",
+            "properties": {
+                "capacity": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "status": {
+                    "$ref": "#/definitions/domain.Status"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.Role": {
+            "type": "string",
+            "enum": [
+                "member",
+                "staff",
+                "admin"
+            ],
+            "x-enum-varnames": [
+                "Member",
+                "Staff",
+                "Admin"
+            ]
+        },
+        "domain.RoleGrant": {
+            "type": "object",
+            "required": [
+                "role",
+                "type",
+                "value"
+            ],
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "description": "Empty for the bootstrap admin",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "role": {
+                    "enum": [
+                        "member",
+                        "staff",
+                        "admin"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.Role"
+                        }
+                    ]
+                },
+                "type": {
+                    "enum": [
+                        "lineId"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.RoleGrantType"
                         }
-                    }
+                    ]
+                },
+                "value": {
+                    "description": "The LIFF ID",
+                    "type": "string",
+                    "maxLength": 100
                 }
             }
-        }
-    },
-    "definitions": {
-        "domain.Education": {
+        },
+        "domain.RoleGrantType": {
             "type": "string",
             "enum": [
-                "studying",
-                "graduated"
+                "lineId"
             ],
             "x-enum-varnames": [
-                "EducationStudying",
-                "EducationGraduated"
+                "GrantByLineID"
             ]
         },
-        "domain.ErrorResponse": {
+        "domain.ScanResult": {
             "type": "object",
             "properties": {
+                "checkInId": {
+                    "type": "string"
+                },
+                "code": {
+                    "description": "Machine-readable reason, as in ErrorResponse",
+                    "type": "string"
+                },
                 "error": {
+                    "description": "Human readable reason the scan was not accepted",
                     "type": "string"
                 },
-                "message": {
+                "hasMedicalNote": {
+                    "description": "Chronic disease or drug allergy on file",
+                    "type": "boolean"
+                },
+                "imageUrl": {
+                    "type": "string"
+                },
+                "isAcrophobic": {
+                    "type": "boolean"
+                },
+                "lastEntered": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/domain.Status"
+                },
+                "ticketType": {
                     "type": "string"
+                },
+                "uid": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "string"
+                },
+                "verdict": {
+                    "$ref": "#/definitions/domain.CheckInResult"
                 }
             }
         },
-        "domain.ImageResponse": {
+        "domain.Session": {
             "type": "object",
             "properties": {
-                "url": {
+                "endsAt": {
+                    "type": "string"
+                },
+                "entryPolicy": {
+                    "$ref": "#/definitions/domain.EntryPolicy"
+                },
+                "eventId": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "startsAt": {
                     "type": "string"
                 }
             }
         },
-        "domain.QrResponse": {
+        "domain.SignInRequest": {
             "type": "object",
+            "required": [
+                "idToken"
+            ],
             "properties": {
-                "qrUrl": {
+                "idToken": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.SignedSnapshot": {
+            "type": "object",
+            "properties": {
+                "keyId": {
                     "type": "string"
                 },
-                "userId": {
+                "payload": {
+                    "type": "object"
+                },
+                "signature": {
+                    "description": "Base64 Ed25519 signature of payload",
                     "type": "string"
                 }
             }
         },
-        "domain.Role": {
-            "type": "string",
-            "enum": [
-                "member",
-                "staff",
-                "admin"
-            ],
-            "x-enum-varnames": [
-                "Member",
-                "Staff",
-                "Admin"
-            ]
+        "domain.SnapshotKey": {
+            "type": "object",
+            "properties": {
+                "keyId": {
+                    "type": "string"
+                },
+                "publicKey": {
+                    "description": "Base64 Ed25519 public key",
+                    "type": "string"
+                }
+            }
         },
         "domain.Status": {
             "type": "string",
@@ -790,17 +4180,200 @@ const docTemplate = `{
                 "StatusGeneralStudent"
             ]
         },
+        "domain.StreamTokenResponse": {
+            "type": "object",
+            "properties": {
+                "expiresAt": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.SyncRequest": {
+            "type": "object",
+            "properties": {
+                "deviceId": {
+                    "type": "string"
+                },
+                "scans": {
+                    "description": "Scans are checked one by one; see SyncResult",
+                    "type": "array",
+                    "maxItems": 500,
+                    "items": {
+                        "$ref": "#/definitions/domain.OfflineScan"
+                    }
+                }
+            }
+        },
+        "domain.SyncResponse": {
+            "type": "object",
+            "properties": {
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.SyncResult"
+                    }
+                }
+            }
+        },
+        "domain.SyncResult": {
+            "type": "object",
+            "properties": {
+                "conflictWith": {
+                    "description": "The accepted scan this one clashes with",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.CheckIn"
+                        }
+                    ]
+                },
+                "id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "result": {
+                    "$ref": "#/definitions/domain.CheckInResult"
+                }
+            }
+        },
         "domain.TokenResponse": {
             "type": "object",
             "properties": {
                 "accessToken": {
                     "type": "string"
                 },
+                "expiresAt": {
+                    "description": "Expiry of the access token",
+                    "type": "string"
+                },
+                "refreshToken": {
+                    "type": "string"
+                },
                 "userId": {
                     "type": "string"
                 }
             }
         },
+        "domain.UpdateProfileRequest": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "type": "string"
+                },
+                "chronicDisease": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "drugAllergy": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "education": {
+                    "$ref": "#/definitions/domain.Education"
+                },
+                "email": {
+                    "type": "string",
+                    "maxLength": 254
+                },
+                "faculty": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "foodLimitation": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "graduatedYear": {
+                    "type": "string"
+                },
+                "isAcroPhobia": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "sizeJersey": {
+                    "$ref": "#/definitions/domain.JerseySize"
+                },
+                "university": {
+                    "type": "string",
+                    "maxLength": 200
+                }
+            }
+        },
+        "domain.UpdateSessionRequest": {
+            "type": "object",
+            "properties": {
+                "endsAt": {
+                    "type": "string"
+                },
+                "entryPolicy": {
+                    "enum": [
+                        "single",
+                        "reentry",
+                        "paired"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.EntryPolicy"
+                        }
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "minLength": 1
+                },
+                "startsAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.UpdateZoneRequest": {
+            "type": "object",
+            "required": [
+                "allowedTicketTypes"
+            ],
+            "properties": {
+                "allowedRoles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Role"
+                    }
+                },
+                "allowedStatuses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Status"
+                    }
+                },
+                "allowedTicketTypes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "capacity": {
+                    "description": "0 means unlimited",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                }
+            }
+        },
         "domain.User": {
             "type": "object",
             "properties": {
@@ -848,26 +4421,136 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "phone": {
-                    "description": "Make phone unique",
+                    "description": "Make phone unique; stored in local form, e.g. 0812345678",
                     "type": "string"
                 },
                 "registeredAt": {
                     "type": "string"
                 },
+                "registration": {
+                    "$ref": "#/definitions/domain.Registration"
+                },
                 "role": {
                     "$ref": "#/definitions/domain.Role"
                 },
                 "sizeJersey": {
-                    "type": "string"
+                    "$ref": "#/definitions/domain.JerseySize"
                 },
                 "status": {
                     "$ref": "#/definitions/domain.Status"
                 },
+                "ticketType": {
+                    "description": "Assigned by admins, e.g. vip or card_stunt",
+                    "type": "string"
+                },
                 "uid": {
                     "type": "string"
                 },
                 "university": {
                     "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.UserPage": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.User"
+                    }
+                }
+            }
+        },
+        "domain.VoidCheckInRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "domain.WaitlistPosition": {
+            "type": "object",
+            "properties": {
+                "position": {
+                    "description": "1 is next in line; 0 if the user is confirmed",
+                    "type": "integer"
+                },
+                "registration": {
+                    "$ref": "#/definitions/domain.Registration"
+                },
+                "status": {
+                    "$ref": "#/definitions/domain.Status"
+                },
+                "waitlisted": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.Zone": {
+            "type": "object",
+            "properties": {
+                "allowedRoles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Role"
+                    }
+                },
+                "allowedStatuses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Status"
+                    }
+                },
+                "allowedTicketTypes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "capacity": {
+                    "description": "0 means unlimited",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ZoneOccupancy": {
+            "type": "object",
+            "properties": {
+                "capacity": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "occupancy": {
+                    "type": "integer"
+                },
+                "zoneId": {
+                    "type": "string"
                 }
             }
         }
@@ -0,0 +1,99 @@
+package domain
+
+import "time"
+
+type CheckInResult string
+
+const (
+	CheckInAccepted  CheckInResult = "accepted"
+	CheckInDuplicate CheckInResult = "duplicate"
+	CheckInRejected  CheckInResult = "rejected"
+	CheckInConflict  CheckInResult = "conflict" // Admitted offline, but the server would not have let them in
+	CheckInVoided    CheckInResult = "voided"   // Accepted, then reverted by staff
+)
+
+type CheckInDirection string
+
+const (
+	DirectionEntry CheckInDirection = "entry"
+	DirectionExit  CheckInDirection = "exit"
+)
+
+type CheckInSource string
+
+const (
+	SourceOnline  CheckInSource = "online"
+	SourceOffline CheckInSource = "offline"
+	SourceManual  CheckInSource = "manual" // Looked up by staff instead of scanned
+)
+
+// Reason codes recorded with check-ins that were not accepted
+const (
+	ReasonInvalidQR       = "invalid_qr"
+	ReasonExpiredQR       = "expired_qr"
+	ReasonReplayedQR      = "replayed_qr"
+	ReasonUserNotFound    = "user_not_found"
+	ReasonAlreadyEntered  = "already_entered"
+	ReasonNoSession       = "no_active_session"
+	ReasonZoneFull        = "zone_full"
+	ReasonNotInside       = "not_inside"
+	ReasonNotPermitted    = "zone_not_permitted"
+	ReasonUnknownGate     = "unknown_gate"
+	ReasonWaitlisted      = "waitlisted"
+	ReasonInvalidScan     = "invalid_scan"
+	ReasonRejectedOffline = "rejected_offline"
+	ReasonInternalError   = "internal_error"
+)
+
+// CheckIn is a log entry written for every scan attempt at a gate
+type CheckIn struct {
+	ID         string           `json:"id" gorm:"primaryKey"`
+	UserID     string           `json:"userId" gorm:"index"` // Empty if the QR code could not be resolved to a user
+	SessionID  string           `json:"sessionId" gorm:"index"`
+	ScannerID  string           `json:"scannerId" gorm:"index"`
+	Gate       string           `json:"gate" gorm:"index"`
+	ZoneID     string           `json:"zoneId" gorm:"index"`
+	DeviceID   string           `json:"deviceId"`
+	Direction  CheckInDirection `json:"direction"`
+	Source     CheckInSource    `json:"source"`
+	Result     CheckInResult    `json:"result" gorm:"index"`
+	Reason     string           `json:"reason,omitempty"`
+	ScannedAt  time.Time        `json:"scannedAt" gorm:"index"`
+	VoidedBy   string           `json:"voidedBy,omitempty"`
+	VoidedAt   *time.Time       `json:"voidedAt,omitempty"`
+	VoidReason string           `json:"voidReason,omitempty"`
+}
+
+type ManualCheckInRequest struct {
+	UserID    string           `json:"userId" validate:"required"`
+	Gate      string           `json:"gate" validate:"max=100"`
+	DeviceID  string           `json:"deviceId" validate:"max=100"`
+	Direction CheckInDirection `json:"direction" validate:"omitempty,oneof=entry exit"`
+}
+
+type VoidCheckInRequest struct {
+	Reason string `json:"reason" validate:"required,max=500"`
+}
+
+// ScanContext identifies who scanned a QR code and where
+type ScanContext struct {
+	ScannerID string
+	Gate      string
+	DeviceID  string
+	Direction CheckInDirection
+}
+
+type CheckInFilter struct {
+	UserID    string
+	SessionID string
+	ScannerID string
+	Gate      string
+	ZoneID    string
+	Direction CheckInDirection
+	Source    CheckInSource
+	Result    CheckInResult
+	From      *time.Time
+	To        *time.Time
+	Limit     int
+	Offset    int
+}
@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+// CheckInEvent is pushed to dashboards for every scan attempt
+type CheckInEvent struct {
+	CheckIn  CheckIn `json:"checkIn"`
+	UserName string  `json:"userName"`
+	Status   Status  `json:"status"` // Empty if the user could not be resolved
+}
+
+type MinuteCount struct {
+	Minute time.Time `json:"minute"`
+	Count  int64     `json:"count"`
+}
+
+// DashboardStats are rolling aggregates of accepted entries since From
+type DashboardStats struct {
+	From      time.Time        `json:"from"`
+	Total     int64            `json:"total"`
+	PerMinute []MinuteCount    `json:"perMinute"`
+	PerGate   map[string]int64 `json:"perGate"`
+	PerStatus map[Status]int64 `json:"perStatus"`
+}
@@ -1,13 +1,122 @@
 package domain
 
-import "errors"
+import "fmt"
 
 // ErrorResponse represents a basic error structure
 type ErrorResponse struct {
-	Error   string  `json:"error"`
-	Message *string `json:"message,omitempty"`
+	Error   string       `json:"error"`
+	Code    string       `json:"code,omitempty"` // Machine-readable, e.g. user_not_found
+	Message *string      `json:"message,omitempty"`
+	Fields  []FieldError `json:"fields,omitempty"` // Every invalid field, for validation errors
 }
 
-var ErrUserAlreadyEntered = errors.New("user has already entered")
-var ErrUserNotFound = errors.New("user not found")
-var ErrUserAlreadyStaff = errors.New("user is already a staff")
+// FieldError says why a request field is invalid
+type FieldError struct {
+	Field   string `json:"field"`   // Path of the field in the request, e.g. scans[0].id
+	Rule    string `json:"rule"`    // The rule it broke, e.g. required or oneof
+	Message string `json:"message"` // Human readable reason
+}
+
+// ValidationError is returned when one or more request fields are invalid
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid input: %d invalid fields", len(e.Fields))
+}
+
+// Add records that field broke rule
+func (e *ValidationError) Add(field, rule, message string) {
+	e.Fields = append(e.Fields, FieldError{Field: field, Rule: rule, Message: message})
+}
+
+// Err returns e if any field is invalid and nil otherwise
+func (e *ValidationError) Err() error {
+	if len(e.Fields) == 0 {
+		return nil
+	}
+	return e
+}
+
+// ErrorKind says what went wrong in terms clients can act on, so errors can
+// be reported with the right HTTP status
+type ErrorKind int
+
+const (
+	KindInternal      ErrorKind = iota
+	KindInvalid                 // The request is malformed or breaks a rule
+	KindUnauthorized            // The caller could not be identified
+	KindForbidden               // The caller may not do this
+	KindNotFound                // Something the request refers to does not exist
+	KindConflict                // The request conflicts with the current state
+	KindUnprocessable           // The request is well-formed, but what it refers to is refused, e.g. a scanned QR code
+)
+
+// Error is an error reported to clients. Code is a stable, machine-readable
+// identifier, e.g. user_not_found; Message is for people.
+type Error struct {
+	Kind    ErrorKind
+	Code    string
+	Message string
+}
+
+func NewError(kind ErrorKind, code, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message}
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// ErrNotFound and ErrConflict are reported for missing records and unique
+// violations that have no more specific error
+var ErrNotFound = NewError(KindNotFound, "not_found", "not found")
+var ErrConflict = NewError(KindConflict, "conflict", "conflicts with an existing record")
+
+var ErrUserAlreadyEntered = NewError(KindConflict, "already_entered", "user has already entered")
+var ErrUserNotFound = NewError(KindNotFound, "user_not_found", "user not found")
+var ErrUserAlreadyStaff = NewError(KindConflict, "already_staff", "user is already a staff")
+var ErrInvalidIDToken = NewError(KindUnauthorized, "invalid_id_token", "invalid ID token")
+var ErrInvalidRefreshToken = NewError(KindUnauthorized, "invalid_refresh_token", "invalid or expired refresh token")
+var ErrRefreshTokenReused = NewError(KindUnauthorized, "refresh_token_reused", "refresh token has already been used")
+var ErrInvalidQRToken = NewError(KindUnprocessable, "invalid_qr", "invalid QR code")
+var ErrQRTokenExpired = NewError(KindUnprocessable, "expired_qr", "QR code has expired")
+var ErrQRTokenReplayed = NewError(KindConflict, "replayed_qr", "QR code has already been scanned")
+var ErrInvalidQRImageOptions = NewError(KindInvalid, "invalid_qr_image_options", "invalid QR image options")
+var ErrEventNotFound = NewError(KindNotFound, "event_not_found", "event not found")
+var ErrSessionNotFound = NewError(KindNotFound, "session_not_found", "session not found")
+var ErrNoActiveSession = NewError(KindConflict, "no_active_session", "no session is open for entry")
+var ErrInvalidEvent = NewError(KindInvalid, "invalid_event", "invalid event")
+var ErrInvalidSession = NewError(KindInvalid, "invalid_session", "invalid session")
+var ErrSessionOverlap = NewError(KindConflict, "session_overlap", "session overlaps another session")
+var ErrZoneNotFound = NewError(KindNotFound, "zone_not_found", "zone not found")
+var ErrGateNotFound = NewError(KindNotFound, "gate_not_found", "gate not found")
+var ErrZoneInUse = NewError(KindConflict, "zone_in_use", "zone still has gates")
+var ErrInvalidZone = NewError(KindInvalid, "invalid_zone", "invalid zone")
+var ErrInvalidGate = NewError(KindInvalid, "invalid_gate", "invalid gate")
+var ErrZoneFull = NewError(KindConflict, "zone_full", "zone is full")
+var ErrUserNotInside = NewError(KindConflict, "not_inside", "user is not inside")
+var ErrInvalidDirection = NewError(KindInvalid, "invalid_direction", "invalid scan direction")
+var ErrZoneAccessDenied = NewError(KindUnprocessable, "zone_not_permitted", "user is not permitted in this zone")
+var ErrStaffNotAssigned = NewError(KindNotFound, "staff_not_assigned", "staff is not assigned to a gate")
+var ErrInvalidStaff = NewError(KindInvalid, "not_staff", "user is not staff")
+var ErrCheckInNotFound = NewError(KindNotFound, "check_in_not_found", "check-in not found")
+var ErrInvalidCursor = NewError(KindInvalid, "invalid_cursor", "invalid sync cursor")
+var ErrInvalidSyncBatch = NewError(KindInvalid, "invalid_sync_batch", "invalid sync batch")
+var ErrCheckInNotVoidable = NewError(KindConflict, "check_in_not_voidable", "only accepted check-ins can be voided")
+var ErrCheckInAlreadyVoided = NewError(KindConflict, "check_in_already_voided", "check-in has already been voided")
+var ErrInvalidVoidReason = NewError(KindInvalid, "invalid_void_reason", "a reason is required to void a check-in")
+var ErrUIDTaken = NewError(KindConflict, "uid_taken", "UID is already taken")
+var ErrInvalidUID = NewError(KindInvalid, "invalid_uid", "invalid UID")
+var ErrRoleGrantNotFound = NewError(KindNotFound, "role_grant_not_found", "role grant not found")
+var ErrInvalidRoleGrant = NewError(KindInvalid, "invalid_role_grant", "invalid role grant")
+var ErrInvitationNotFound = NewError(KindNotFound, "invitation_not_found", "invitation not found")
+var ErrInvitationExpired = NewError(KindConflict, "invitation_expired", "invitation has expired")
+var ErrInvitationRevoked = NewError(KindConflict, "invitation_revoked", "invitation has been revoked")
+var ErrInvitationUsedUp = NewError(KindConflict, "invitation_used_up", "invitation has been used up")
+var ErrInvalidInvitation = NewError(KindInvalid, "invalid_invitation", "invalid invitation")
+var ErrInvalidQuota = NewError(KindInvalid, "invalid_quota", "invalid registration quota")
+var ErrQuotaNotFound = NewError(KindNotFound, "quota_not_found", "registration quota not found")
+var ErrUserWaitlisted = NewError(KindUnprocessable, "waitlisted", "user is on the waitlist")
+var ErrPhoneTaken = NewError(KindConflict, "phone_taken", "phone number is already registered")
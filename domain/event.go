@@ -0,0 +1,66 @@
+package domain
+
+import "time"
+
+type EntryPolicy string
+
+const (
+	EntrySingle  EntryPolicy = "single"  // One accepted entry per session
+	EntryReentry EntryPolicy = "reentry" // Any number of entries
+	EntryPaired  EntryPolicy = "paired"  // Must be scanned out before entering again
+)
+
+// Event groups the sessions attendees are admitted to, e.g. the CU-TU match day
+type Event struct {
+	ID        string    `json:"id" gorm:"primaryKey"`
+	Name      string    `json:"name" validate:"required,max=200"`
+	Timezone  string    `json:"timezone" validate:"required,timezone"` // IANA name, e.g. Asia/Bangkok; session times are shown in it
+	CreatedAt time.Time `json:"createdAt"`
+	Sessions  []Session `json:"sessions,omitempty" gorm:"constraint:OnDelete:CASCADE"`
+}
+
+// Session is a time window of an event during which gates admit attendees
+type Session struct {
+	ID          string      `json:"id" gorm:"primaryKey"`
+	EventID     string      `json:"eventId" gorm:"index"`
+	Name        string      `json:"name"`
+	StartsAt    time.Time   `json:"startsAt" gorm:"index"`
+	EndsAt      time.Time   `json:"endsAt" gorm:"index"`
+	EntryPolicy EntryPolicy `json:"entryPolicy"`
+}
+
+// CreateSessionRequest is the body for adding a session to an event
+type CreateSessionRequest struct {
+	Name        string      `json:"name" validate:"required,max=200"`
+	StartsAt    time.Time   `json:"startsAt" validate:"required"`
+	EndsAt      time.Time   `json:"endsAt" validate:"required,gtfield=StartsAt"`
+	EntryPolicy EntryPolicy `json:"entryPolicy" validate:"required,oneof=single reentry paired"`
+}
+
+// Session builds the session to create from the request
+func (r *CreateSessionRequest) Session() *Session {
+	return &Session{
+		Name:        r.Name,
+		StartsAt:    r.StartsAt,
+		EndsAt:      r.EndsAt,
+		EntryPolicy: r.EntryPolicy,
+	}
+}
+
+// UpdateSessionRequest holds the session fields to change. Fields left out are
+// not changed.
+type UpdateSessionRequest struct {
+	Name        *string      `json:"name" validate:"omitempty,min=1,max=200"`
+	StartsAt    *time.Time   `json:"startsAt"`
+	EndsAt      *time.Time   `json:"endsAt"`
+	EntryPolicy *EntryPolicy `json:"entryPolicy" validate:"omitempty,oneof=single reentry paired"`
+}
+
+// IsValid reports whether p is a known entry policy
+func (p EntryPolicy) IsValid() bool {
+	switch p {
+	case EntrySingle, EntryReentry, EntryPaired:
+		return true
+	}
+	return false
+}
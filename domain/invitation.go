@@ -0,0 +1,45 @@
+package domain
+
+import "time"
+
+// Invitation is a code that lets its holders register with a granted status
+// or ticket type. Codes are generated in batches and can be used MaxUses times.
+type Invitation struct {
+	Code       string     `json:"code" gorm:"primaryKey"`
+	BatchID    string     `json:"batchId" gorm:"index"`
+	Label      string     `json:"label"` // What the batch is for, e.g. "Alumni association"
+	MaxUses    int        `json:"maxUses"`
+	Uses       int        `json:"uses"`
+	ExpiresAt  *time.Time `json:"expiresAt"`
+	Status     *Status    `json:"status"`     // Status given to users who redeem the code, if any
+	TicketType string     `json:"ticketType"` // Ticket type given to users who redeem the code, which zones can allow
+	CreatedBy  string     `json:"createdBy"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	RevokedAt  *time.Time `json:"revokedAt"`
+}
+
+type InvitationRedemption struct {
+	ID         string    `json:"id" gorm:"primaryKey"`
+	Code       string    `json:"code" gorm:"index"`
+	UserID     string    `json:"userId" gorm:"uniqueIndex"`
+	RedeemedAt time.Time `json:"redeemedAt"`
+}
+
+// RedemptionDetail is a redemption with the user who made it
+type RedemptionDetail struct {
+	Code       string    `json:"code"`
+	UserID     string    `json:"userId"`
+	UID        string    `json:"uid"`
+	Name       string    `json:"name"`
+	Phone      string    `json:"phone"`
+	RedeemedAt time.Time `json:"redeemedAt"`
+}
+
+type CreateInvitationsRequest struct {
+	Count      int        `json:"count" validate:"min=1,max=1000"`
+	Label      string     `json:"label" validate:"max=100"`
+	MaxUses    int        `json:"maxUses" validate:"min=1"`
+	ExpiresAt  *time.Time `json:"expiresAt"`
+	Status     *Status    `json:"status" validate:"omitempty,oneof=chula_student alumni general_public general_student"`
+	TicketType string     `json:"ticketType" validate:"max=50"`
+}
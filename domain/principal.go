@@ -0,0 +1,18 @@
+package domain
+
+// Principal is the authenticated caller of a request
+type Principal struct {
+	UserID  string
+	Role    Role
+	TokenID string
+}
+
+// HasRole reports whether the principal holds any of the given roles
+func (p Principal) HasRole(roles ...Role) bool {
+	for _, role := range roles {
+		if p.Role == role {
+			return true
+		}
+	}
+	return false
+}
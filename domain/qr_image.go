@@ -0,0 +1,24 @@
+package domain
+
+type QrImageFormat string
+
+const (
+	QrImagePNG QrImageFormat = "png"
+	QrImageSVG QrImageFormat = "svg"
+)
+
+type QrImageOptions struct {
+	Format QrImageFormat
+	Size   int    // Width and height in pixels
+	Level  string // Error-correction level: low, medium, high or highest
+	Logo   bool   // Draw the configured logo in the centre
+}
+
+// QrImage is a rendered QR code. Data is empty when NotModified is set.
+type QrImage struct {
+	Data         []byte
+	ContentType  string
+	ETag         string
+	RefreshAfter int // Seconds until a new code is issued
+	NotModified  bool
+}
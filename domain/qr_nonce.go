@@ -0,0 +1,10 @@
+package domain
+
+import "time"
+
+// QrNonce records a QR token nonce that has been scanned, to reject replays
+type QrNonce struct {
+	Nonce     string    `json:"nonce" gorm:"primaryKey"`
+	UserID    string    `json:"userId"`
+	ExpiresAt time.Time `json:"expiresAt" gorm:"index"`
+}
@@ -1,6 +1,11 @@
 package domain
 
+import "time"
+
 type QrResponse struct {
-	UserID string `json:"userId"`
-	QrURL  string `json:"qrUrl"`
+	UserID       string    `json:"userId"`
+	QrURL        string    `json:"qrUrl"`
+	Token        string    `json:"token"`
+	ExpiresAt    time.Time `json:"expiresAt"`
+	RefreshAfter int       `json:"refreshAfter"` // Seconds until the app should fetch a new code
 }
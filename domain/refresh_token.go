@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// RefreshToken is a server-side record of an issued refresh token. Tokens
+// rotated from the same sign-in share a FamilyID so that reuse of an already
+// rotated token can revoke the whole chain.
+type RefreshToken struct {
+	ID        string     `json:"id" gorm:"primaryKey"`
+	FamilyID  string     `json:"familyId" gorm:"index"`
+	UserID    string     `json:"userId" gorm:"index"`
+	TokenHash string     `json:"-" gorm:"unique"`
+	ExpiresAt time.Time  `json:"expiresAt"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UsedAt    *time.Time `json:"usedAt"`    // Set once the token has been rotated
+	RevokedAt *time.Time `json:"revokedAt"` // Set on logout or reuse detection
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refreshToken" validate:"required"`
+}
@@ -0,0 +1,67 @@
+package domain
+
+import "strings"
+
+// RegisterRequest is the multipart form submitted to register. Blank optional
+// fields are treated as not given.
+type RegisterRequest struct {
+	IDToken        string `form:"idToken" validate:"required"`
+	Name           string `form:"name" validate:"required,max=100"`
+	Email          string `form:"email" validate:"omitempty,email,max=254"`
+	Phone          string `form:"phone" validate:"required,thaiphone"`
+	University     string `form:"university" validate:"max=200"`
+	SizeJersey     string `form:"sizeJersey" validate:"omitempty,oneof=XS S M L XL 2XL 3XL"`
+	FoodLimitation string `form:"foodLimitation" validate:"required,max=500"`
+	InvitationCode string `form:"invitationCode" validate:"max=32"`
+	Status         string `form:"status" validate:"required,oneof=chula_student alumni general_public general_student"`
+	Age            string `form:"age" validate:"omitempty,age"`
+	ChronicDisease string `form:"chronicDisease" validate:"max=500"`
+	DrugAllergy    string `form:"drugAllergy" validate:"max=500"`
+	GraduatedYear  string `form:"graduatedYear" validate:"required_if=Status alumni,omitempty,number,len=4"`
+	Faculty        string `form:"faculty" validate:"required_if=Status chula_student,max=100"`
+	Education      string `form:"education" validate:"omitempty,oneof=studying graduated"`
+	IsAcroPhobia   bool   `form:"isAcroPhobia"`
+}
+
+// Normalize trims every field and puts enum fields in the case they are defined in
+func (r *RegisterRequest) Normalize() {
+	for _, field := range []*string{
+		&r.IDToken, &r.Name, &r.Email, &r.Phone, &r.University, &r.SizeJersey,
+		&r.FoodLimitation, &r.InvitationCode, &r.Status, &r.Age, &r.ChronicDisease,
+		&r.DrugAllergy, &r.GraduatedYear, &r.Faculty, &r.Education,
+	} {
+		*field = strings.TrimSpace(*field)
+	}
+	r.SizeJersey = strings.ToUpper(r.SizeJersey)
+	r.Status = strings.ToLower(r.Status)
+	r.Education = strings.ToLower(r.Education)
+}
+
+// User builds the user to register from the request
+func (r *RegisterRequest) User() *User {
+	isAcroPhobia := r.IsAcroPhobia
+	return &User{
+		Name:           r.Name,
+		Email:          optional(r.Email),
+		Phone:          r.Phone,
+		University:     optional(r.University),
+		SizeJersey:     (*JerseySize)(optional(r.SizeJersey)),
+		FoodLimitation: r.FoodLimitation,
+		InvitationCode: optional(r.InvitationCode),
+		Status:         Status(r.Status),
+		Age:            optional(r.Age),
+		ChronicDisease: optional(r.ChronicDisease),
+		DrugAllergy:    optional(r.DrugAllergy),
+		GraduatedYear:  optional(r.GraduatedYear),
+		Faculty:        optional(r.Faculty),
+		Education:      (*Education)(optional(r.Education)),
+		IsAcroPhobia:   &isAcroPhobia,
+	}
+}
+
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
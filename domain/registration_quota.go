@@ -0,0 +1,28 @@
+package domain
+
+import "time"
+
+// RegistrationQuota caps how many users of a status get a place at the event.
+// Users who register once it is full are waitlisted. Statuses without a quota
+// are unlimited.
+type RegistrationQuota struct {
+	Status    Status    `json:"status" gorm:"primaryKey"`
+	Capacity  int       `json:"capacity" validate:"min=0"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// QuotaUsage is how much of a status's quota is taken
+type QuotaUsage struct {
+	Status     Status `json:"status"`
+	Capacity   *int   `json:"capacity"` // Unlimited if null
+	Confirmed  int64  `json:"confirmed"`
+	Waitlisted int64  `json:"waitlisted"`
+}
+
+// WaitlistPosition is where a user stands in their status's waitlist
+type WaitlistPosition struct {
+	Registration Registration `json:"registration"`
+	Status       Status       `json:"status"`
+	Position     int64        `json:"position"` // 1 is next in line; 0 if the user is confirmed
+	Waitlisted   int64        `json:"waitlisted"`
+}
@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+type RoleGrantType string
+
+// Roles are only granted to LIFF IDs, which LINE has verified. Phone numbers
+// are typed in by users when they register, so anyone could claim one.
+const (
+	GrantByLineID RoleGrantType = "lineId"
+)
+
+// RoleGrant gives a role to whoever registers with a LIFF ID
+type RoleGrant struct {
+	ID        string        `json:"id" gorm:"primaryKey"`
+	Type      RoleGrantType `json:"type" gorm:"uniqueIndex:idx_role_grants_subject" validate:"required,oneof=lineId"`
+	Value     string        `json:"value" gorm:"uniqueIndex:idx_role_grants_subject" validate:"required,max=100"` // The LIFF ID
+	Role      Role          `json:"role" validate:"required,oneof=member staff admin"`
+	CreatedBy string        `json:"createdBy"` // Empty for the bootstrap admin
+	CreatedAt time.Time     `json:"createdAt"`
+}
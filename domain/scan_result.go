@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// GateAttendee is what gate staff may see about an attendee: enough to
+// recognise them and look after them, without contact or medical details
+type GateAttendee struct {
+	UserID         string     `json:"userId"`
+	UID            string     `json:"uid"`
+	Name           string     `json:"name"`
+	ImageURL       *string    `json:"imageUrl"`
+	Status         Status     `json:"status"`
+	TicketType     string     `json:"ticketType"`
+	LastEntered    *time.Time `json:"lastEntered"`
+	HasMedicalNote bool       `json:"hasMedicalNote"` // Chronic disease or drug allergy on file
+	IsAcrophobic   bool       `json:"isAcrophobic"`
+}
+
+// ScanResult is returned to the scanning device for every decided scan
+type ScanResult struct {
+	GateAttendee
+	CheckInID string        `json:"checkInId"`
+	Verdict   CheckInResult `json:"verdict"`
+	Reason    string        `json:"reason,omitempty"`
+	Error     string        `json:"error,omitempty"` // Human readable reason the scan was not accepted
+	Code      string        `json:"code,omitempty"`  // Machine-readable reason, as in ErrorResponse
+}
+
+func NewGateAttendee(user User) GateAttendee {
+	return GateAttendee{
+		UserID:         user.ID,
+		UID:            user.UID,
+		Name:           user.Name,
+		ImageURL:       user.ImageURL,
+		Status:         user.Status,
+		TicketType:     user.TicketType,
+		LastEntered:    user.LastEntered,
+		HasMedicalNote: hasText(user.ChronicDisease) || hasText(user.DrugAllergy),
+		IsAcrophobic:   user.IsAcroPhobia != nil && *user.IsAcroPhobia,
+	}
+}
+
+func hasText(s *string) bool {
+	return s != nil && strings.TrimSpace(*s) != ""
+}
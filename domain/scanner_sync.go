@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// SnapshotAttendee is what an offline scanner needs to know about an attendee
+type SnapshotAttendee struct {
+	ID          string     `json:"id"`
+	UID         string     `json:"uid"`
+	Name        string     `json:"name"`
+	Status      Status     `json:"status"`
+	TicketType  string     `json:"ticketType"`
+	PhotoHash   string     `json:"photoHash"` // Changes whenever the photo does
+	LastEntered *time.Time `json:"lastEntered"`
+	Eligible    bool       `json:"eligible"` // Whether the attendee may enter through the scanner's gate
+}
+
+// Snapshot is the set of attendees a scanner admits for a session. A delta
+// snapshot only holds attendees changed, and IDs of users removed, since the
+// cursor; if the rules changed since, a full snapshot is returned instead.
+type Snapshot struct {
+	Session      Session            `json:"session"`
+	RulesVersion string             `json:"rulesVersion"` // Changes whenever the session or the gate's zone rules do
+	GeneratedAt  time.Time          `json:"generatedAt"`
+	Cursor       string             `json:"cursor"` // Pass back to fetch the next delta
+	Full         bool               `json:"full"`   // Replaces what the device holds, e.g. because the rules changed
+	Attendees    []SnapshotAttendee `json:"attendees"`
+	Removed      []string           `json:"removed"`
+}
+
+// SignedSnapshot carries the JSON encoded Snapshot exactly as signed
+type SignedSnapshot struct {
+	Payload   json.RawMessage `json:"payload" swaggertype:"object"`
+	Signature string          `json:"signature"` // Base64 Ed25519 signature of payload
+	KeyID     string          `json:"keyId"`
+}
+
+type SnapshotKey struct {
+	KeyID     string `json:"keyId"`
+	PublicKey string `json:"publicKey"` // Base64 Ed25519 public key
+}
+
+// OfflineScan is a scan a device made while offline
+type OfflineScan struct {
+	ID        string           `json:"id"` // Generated by the device; re-uploading the same ID is a no-op
+	UserID    string           `json:"userId"`
+	Token     string           `json:"token"` // The QR code scanned; required for scans the device admitted
+	Gate      string           `json:"gate"`
+	Direction CheckInDirection `json:"direction"`
+	ScannedAt time.Time        `json:"scannedAt"`
+	Admitted  bool             `json:"admitted"` // Whether the device let the attendee through
+	Reason    string           `json:"reason"`   // Why the device turned the attendee away
+}
+
+type SyncRequest struct {
+	DeviceID string        `json:"deviceId"`
+	Scans    []OfflineScan `json:"scans" validate:"max=500"` // Scans are checked one by one; see SyncResult
+}
+
+type SyncResult struct {
+	ID           string        `json:"id"`
+	Result       CheckInResult `json:"result"`
+	Reason       string        `json:"reason,omitempty"`
+	ConflictWith *CheckIn      `json:"conflictWith,omitempty"` // The accepted scan this one clashes with
+}
+
+type SyncResponse struct {
+	Results []SyncResult `json:"results"`
+}
+
+// UserTombstone records a deleted user so delta snapshots can tell devices to drop them
+type UserTombstone struct {
+	UserID    string    `json:"userId" gorm:"primaryKey"`
+	DeletedAt time.Time `json:"deletedAt" gorm:"index"`
+}
@@ -0,0 +1,5 @@
+package domain
+
+type SignInRequest struct {
+	IDToken string `json:"idToken" validate:"required"`
+}
@@ -1,6 +1,16 @@
 package domain
 
+import "time"
+
 type TokenResponse struct {
-	UserID      string `json:"userId"`
-	AccessToken string `json:"accessToken"`
+	UserID       string    `json:"userId"`
+	AccessToken  string    `json:"accessToken"`
+	RefreshToken string    `json:"refreshToken"`
+	ExpiresAt    time.Time `json:"expiresAt"` // Expiry of the access token
+}
+
+// StreamTokenResponse is a short-lived token for opening the dashboard stream
+type StreamTokenResponse struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expiresAt"`
 }
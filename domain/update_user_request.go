@@ -0,0 +1,27 @@
+package domain
+
+// UpdateProfileRequest holds the fields users may change about themselves.
+// Fields left out are not changed; optional fields are cleared by sending "".
+type UpdateProfileRequest struct {
+	Name           *string     `json:"name" validate:"omitempty,min=1,max=100"`
+	Email          *string     `json:"email" validate:"omitempty,max=254,eq=|email"`
+	Phone          *string     `json:"phone" validate:"omitempty,thaiphone"`
+	University     *string     `json:"university" validate:"omitempty,max=200"`
+	SizeJersey     *JerseySize `json:"sizeJersey" validate:"omitempty,eq=|oneof=XS S M L XL 2XL 3XL"`
+	FoodLimitation *string     `json:"foodLimitation" validate:"omitempty,max=500"`
+	Age            *string     `json:"age" validate:"omitempty,eq=|age"`
+	ChronicDisease *string     `json:"chronicDisease" validate:"omitempty,max=500"`
+	DrugAllergy    *string     `json:"drugAllergy" validate:"omitempty,max=500"`
+	GraduatedYear  *string     `json:"graduatedYear" validate:"omitempty,eq=|len=4,eq=|number"`
+	Faculty        *string     `json:"faculty" validate:"omitempty,max=100"`
+	Education      *Education  `json:"education" validate:"omitempty,eq=|oneof=studying graduated"`
+	IsAcroPhobia   *bool       `json:"isAcroPhobia"`
+}
+
+// AdminUpdateUserRequest holds the fields admins may change about a user. The
+// role is changed with UpdateRole or AddStaff, which also sign the user out.
+type AdminUpdateUserRequest struct {
+	UpdateProfileRequest
+	Status     *Status `json:"status" validate:"omitempty,oneof=chula_student alumni general_public general_student"`
+	TicketType *string `json:"ticketType" validate:"omitempty,max=50"`
+}
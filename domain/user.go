@@ -5,6 +5,7 @@ import "time"
 type Role string
 type Status string
 type Education string
+type JerseySize string
 
 const (
 	Member Role = "member"
@@ -12,6 +13,15 @@ const (
 	Admin  Role = "admin"
 )
 
+// IsValid reports whether r is a known role
+func (r Role) IsValid() bool {
+	switch r {
+	case Member, Staff, Admin:
+		return true
+	}
+	return false
+}
+
 const (
 	StatusChulaStudent   Status = "chula_student"
 	StatusAlumni         Status = "alumni"
@@ -19,31 +29,89 @@ const (
 	StatusGeneralStudent Status = "general_student"
 )
 
+// IsValid reports whether s is a known status
+func (s Status) IsValid() bool {
+	switch s {
+	case StatusChulaStudent, StatusAlumni, StatusGeneralPublic, StatusGeneralStudent:
+		return true
+	}
+	return false
+}
+
+// Registration is whether a user has a place at the event or is waiting for one
+type Registration string
+
+const (
+	RegistrationConfirmed  Registration = "confirmed"
+	RegistrationWaitlisted Registration = "waitlisted"
+)
+
 const (
 	EducationStudying  Education = "studying"
 	EducationGraduated Education = "graduated"
 )
 
+// IsValid reports whether e is a known education
+func (e Education) IsValid() bool {
+	return e == EducationStudying || e == EducationGraduated
+}
+
+const (
+	JerseyXS  JerseySize = "XS"
+	JerseyS   JerseySize = "S"
+	JerseyM   JerseySize = "M"
+	JerseyL   JerseySize = "L"
+	JerseyXL  JerseySize = "XL"
+	Jersey2XL JerseySize = "2XL"
+	Jersey3XL JerseySize = "3XL"
+)
+
+// IsValid reports whether j is a size the jersey is made in
+func (j JerseySize) IsValid() bool {
+	switch j {
+	case JerseyXS, JerseyS, JerseyM, JerseyL, JerseyXL, Jersey2XL, Jersey3XL:
+		return true
+	}
+	return false
+}
+
 type User struct {
-	ID             string     `json:"id" gorm:"primaryKey"`
-	UID            string     `json:"uid" gorm:"unique"`
-	Name           string     `json:"name"`
-	Email          *string    `json:"email"`
-	Phone          string     `json:"phone" gorm:"unique"` // Make phone unique
-	University     *string    `json:"university"`
-	SizeJersey     *string    `json:"sizeJersey"`
-	FoodLimitation string     `json:"foodLimitation"`
-	InvitationCode *string    `json:"invitationCode"`
-	Age            *string    `json:"age"`
-	ChronicDisease *string    `json:"chronicDisease"`
-	DrugAllergy    *string    `json:"drugAllergy"`
-	Status         Status     `json:"status"`
-	GraduatedYear  *string    `json:"graduatedYear"`
-	Faculty        *string    `json:"faculty"`
-	ImageURL       *string    `json:"imageUrl"`
-	LastEntered    *time.Time `json:"lastEntered"` // Timestamp for the last QR scan
-	RegisteredAt   time.Time  `json:"registeredAt"`
-	Role           Role       `json:"role"`
-	Education      *Education `json:"education"`
-	IsAcroPhobia   *bool      `json:"isAcroPhobia"`
+	ID             string       `json:"id" gorm:"primaryKey"`
+	UID            string       `json:"uid" gorm:"unique"`
+	Name           string       `json:"name"`
+	Email          *string      `json:"email"`
+	Phone          string       `json:"phone" gorm:"unique"` // Make phone unique; stored in local form, e.g. 0812345678
+	University     *string      `json:"university"`
+	SizeJersey     *JerseySize  `json:"sizeJersey"`
+	FoodLimitation string       `json:"foodLimitation"`
+	InvitationCode *string      `json:"invitationCode"`
+	Age            *string      `json:"age"`
+	ChronicDisease *string      `json:"chronicDisease"`
+	DrugAllergy    *string      `json:"drugAllergy"`
+	Status         Status       `json:"status" gorm:"index"`
+	TicketType     string       `json:"ticketType"` // Assigned by admins, e.g. vip or card_stunt
+	GraduatedYear  *string      `json:"graduatedYear"`
+	Faculty        *string      `json:"faculty"`
+	ImageURL       *string      `json:"imageUrl"`
+	LastEntered    *time.Time   `json:"lastEntered"`                 // Timestamp for the last QR scan
+	QrSeq          int          `json:"-" gorm:"not null;default:0"` // Number of QR codes used up, so the next code differs
+	RegisteredAt   time.Time    `json:"registeredAt" gorm:"index"`
+	Registration   Registration `json:"registration" gorm:"index;not null;default:confirmed"`
+	UpdatedAt      time.Time    `json:"updatedAt" gorm:"index;not null;default:CURRENT_TIMESTAMP"`
+	Role           Role         `json:"role" gorm:"index"`
+	Education      *Education   `json:"education"`
+	IsAcroPhobia   *bool        `json:"isAcroPhobia"`
+}
+
+// CheckProfile enforces the rules between fields that registration does, e.g.
+// on a user an update has been merged into
+func (u User) CheckProfile() error {
+	verr := &ValidationError{}
+	if u.Status == StatusAlumni && u.GraduatedYear == nil {
+		verr.Add("graduatedYear", "required_if", "is required when status is alumni")
+	}
+	if u.Status == StatusChulaStudent && u.Faculty == nil {
+		verr.Add("faculty", "required_if", "is required when status is chula_student")
+	}
+	return verr.Err()
 }
@@ -0,0 +1,31 @@
+package domain
+
+import "strings"
+
+type ExportFormat string
+
+const (
+	ExportCSV  ExportFormat = "csv"
+	ExportXLSX ExportFormat = "xlsx"
+)
+
+// UserExportQuery selects the users and columns to export. Columns lists
+// column names separated by commas, e.g. "name,phone,sizeJersey"; blank means
+// every column.
+type UserExportQuery struct {
+	UserFilter
+	Format  ExportFormat `query:"format" validate:"oneof=csv xlsx"`
+	Columns string       `query:"columns" validate:"max=1000"`
+}
+
+// Normalize trims every field and puts enum fields in the case they are defined in
+func (q *UserExportQuery) Normalize() {
+	q.UserFilter.Normalize()
+	q.Format = ExportFormat(strings.ToLower(strings.TrimSpace(string(q.Format))))
+	q.Columns = strings.TrimSpace(q.Columns)
+}
+
+// NewUserExportQuery returns a query for every column of every user as CSV
+func NewUserExportQuery() UserExportQuery {
+	return UserExportQuery{Format: ExportCSV}
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// UserFilter selects users by the query parameters of the same names. Blank
+// fields match every user.
+type UserFilter struct {
+	Search         string       `query:"search" validate:"max=100"` // Part of a name, phone number, UID or email
+	Status         Status       `query:"status" validate:"omitempty,oneof=chula_student alumni general_public general_student"`
+	Role           Role         `query:"role" validate:"omitempty,oneof=member staff admin"`
+	Registration   Registration `query:"registration" validate:"omitempty,oneof=confirmed waitlisted"`
+	University     string       `query:"university" validate:"max=200"`
+	Faculty        string       `query:"faculty" validate:"max=100"`
+	SizeJersey     JerseySize   `query:"sizeJersey" validate:"omitempty,oneof=XS S M L XL 2XL 3XL"`
+	CheckedIn      *bool        `query:"checkedIn"`              // Whether the user has entered the event
+	RegisteredFrom *time.Time   `query:"-" swaggerignore:"true"` // Parsed from registeredFrom (RFC 3339)
+	RegisteredTo   *time.Time   `query:"-" swaggerignore:"true"` // Parsed from registeredTo (RFC 3339)
+}
+
+// Normalize trims every field and puts enum fields in the case they are defined in
+func (f *UserFilter) Normalize() {
+	f.Search = strings.TrimSpace(f.Search)
+	f.Status = Status(strings.ToLower(strings.TrimSpace(string(f.Status))))
+	f.Role = Role(strings.ToLower(strings.TrimSpace(string(f.Role))))
+	f.Registration = Registration(strings.ToLower(strings.TrimSpace(string(f.Registration))))
+	f.University = strings.TrimSpace(f.University)
+	f.Faculty = strings.TrimSpace(f.Faculty)
+	f.SizeJersey = JerseySize(strings.ToUpper(strings.TrimSpace(string(f.SizeJersey))))
+}
+
+// UserListQuery is a page of users matching a filter. Sort names the field to
+// order by, prefixed with - for descending order.
+type UserListQuery struct {
+	UserFilter
+	Sort   string `query:"sort" validate:"oneof=registeredAt -registeredAt updatedAt -updatedAt lastEntered -lastEntered name -name uid -uid"`
+	Limit  int    `query:"limit" validate:"min=1,max=1000"`
+	Offset int    `query:"offset" validate:"min=0"`
+}
+
+// NewUserListQuery returns a query for the first page of newest registrations
+func NewUserListQuery() UserListQuery {
+	return UserListQuery{Sort: "-registeredAt", Limit: 100}
+}
+
+// UserPage is one page of users, with the number of users matching the filter
+type UserPage struct {
+	Users  []User `json:"users"`
+	Total  int64  `json:"total"`
+	Limit  int    `json:"limit"`
+	Offset int    `json:"offset"`
+}
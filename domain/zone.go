@@ -0,0 +1,102 @@
+package domain
+
+import "time"
+
+// Zone is an area of the venue with its own capacity, e.g. the main stand.
+// An empty allow list places no restriction on that attribute.
+type Zone struct {
+	ID                 string   `json:"id" gorm:"primaryKey"`
+	Name               string   `json:"name"`
+	Capacity           int      `json:"capacity"` // 0 means unlimited
+	AllowedStatuses    []Status `json:"allowedStatuses" gorm:"serializer:json"`
+	AllowedRoles       []Role   `json:"allowedRoles" gorm:"serializer:json"`
+	AllowedTicketTypes []string `json:"allowedTicketTypes" gorm:"serializer:json"`
+}
+
+// CreateZoneRequest is the body for creating a zone
+type CreateZoneRequest struct {
+	Name               string   `json:"name" validate:"required,max=100"`
+	Capacity           int      `json:"capacity" validate:"min=0"` // 0 means unlimited
+	AllowedStatuses    []Status `json:"allowedStatuses" validate:"dive,oneof=chula_student alumni general_public general_student"`
+	AllowedRoles       []Role   `json:"allowedRoles" validate:"dive,oneof=member staff admin"`
+	AllowedTicketTypes []string `json:"allowedTicketTypes" validate:"dive,required,max=50"`
+}
+
+// Zone builds the zone to create from the request
+func (r *CreateZoneRequest) Zone() *Zone {
+	return &Zone{
+		Name:               r.Name,
+		Capacity:           r.Capacity,
+		AllowedStatuses:    r.AllowedStatuses,
+		AllowedRoles:       r.AllowedRoles,
+		AllowedTicketTypes: r.AllowedTicketTypes,
+	}
+}
+
+// UpdateZoneRequest holds the zone fields to change. Fields left out are not
+// changed; an allow list is cleared by sending [].
+type UpdateZoneRequest struct {
+	Name               *string   `json:"name" validate:"omitempty,min=1,max=100"`
+	Capacity           *int      `json:"capacity" validate:"omitempty,min=0"` // 0 means unlimited
+	AllowedStatuses    *[]Status `json:"allowedStatuses" validate:"omitempty,dive,oneof=chula_student alumni general_public general_student"`
+	AllowedRoles       *[]Role   `json:"allowedRoles" validate:"omitempty,dive,oneof=member staff admin"`
+	AllowedTicketTypes *[]string `json:"allowedTicketTypes" validate:"omitempty,dive,required,max=50"`
+}
+
+// Restricted reports whether the zone only admits some users
+func (z Zone) Restricted() bool {
+	return len(z.AllowedStatuses) > 0 || len(z.AllowedRoles) > 0 || len(z.AllowedTicketTypes) > 0
+}
+
+// Permits reports whether the user may enter the zone
+func (z Zone) Permits(user User) bool {
+	return allows(z.AllowedStatuses, user.Status) &&
+		allows(z.AllowedRoles, user.Role) &&
+		allows(z.AllowedTicketTypes, user.TicketType)
+}
+
+func allows[T comparable](allowed []T, value T) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	for _, a := range allowed {
+		if a == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Gate is a scanning point that admits into a zone
+type Gate struct {
+	ID     string `json:"id" gorm:"primaryKey" validate:"required,max=50"` // Short code sent by scanners, e.g. north-1
+	Name   string `json:"name" validate:"max=100"`
+	ZoneID string `json:"zoneId" gorm:"index"`
+}
+
+// GateAssignment places a staff member at a gate; their scans are made at that gate
+type GateAssignment struct {
+	StaffID    string    `json:"staffId" gorm:"primaryKey"`
+	GateID     string    `json:"gateId" gorm:"index"`
+	AssignedAt time.Time `json:"assignedAt"`
+}
+
+// Presence is whether a user is currently inside the venue, and in which zone
+type Presence struct {
+	UserID    string    `json:"userId" gorm:"primaryKey"`
+	ZoneID    string    `json:"zoneId" gorm:"index"`
+	Inside    bool      `json:"inside" gorm:"index"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type ZoneOccupancy struct {
+	ZoneID    string `json:"zoneId"`
+	Name      string `json:"name"`
+	Occupancy int64  `json:"occupancy"`
+	Capacity  int    `json:"capacity"`
+}
+
+type Occupancy struct {
+	Total int64           `json:"total"`
+	Zones []ZoneOccupancy `json:"zones"`
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/isd-sgcu/cutu2025-backend/domain"
+	"github.com/isd-sgcu/cutu2025-backend/usecase"
+)
+
+// AuthHandler represents the handler for session-related endpoints
+type AuthHandler struct {
+	Usecase *usecase.AuthUsecase
+}
+
+// NewAuthHandler creates a new AuthHandler
+func NewAuthHandler(usecase *usecase.AuthUsecase) *AuthHandler {
+	return &AuthHandler{Usecase: usecase}
+}
+
+// Refresh godoc
+// @Summary Refresh tokens
+// @Description Exchange a refresh token for a new access and refresh token. Each refresh token can only be used once.
+// @Accept  json
+// @Produce  json
+// @Param body body domain.RefreshTokenRequest true "Refresh Token"
+// @Success 200 {object} domain.TokenResponse
+// @Failure 400 {object} domain.ErrorResponse "Invalid input"
+// @Failure 401 {object} domain.ErrorResponse "Invalid or expired refresh token"
+// @Failure 500 {object} domain.ErrorResponse "Failed to refresh token"
+// @Router /api/auth/refresh [post]
+func (h *AuthHandler) Refresh(c *fiber.Ctx) error {
+	req := new(domain.RefreshTokenRequest)
+	if err := parseBody(c, req); err != nil {
+		return invalidInput(err)
+	}
+
+	tokenResponse, err := h.Usecase.Refresh(req.RefreshToken)
+	if err != nil {
+		return fail(err, "Failed to refresh token")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(tokenResponse)
+}
+
+// Logout godoc
+// @Summary Logout
+// @Description Revoke a refresh token and every token rotated from the same sign-in
+// @Accept  json
+// @Param body body domain.RefreshTokenRequest true "Refresh Token"
+// @Success 204
+// @Failure 400 {object} domain.ErrorResponse "Invalid input"
+// @Failure 401 {object} domain.ErrorResponse "Invalid refresh token"
+// @Failure 500 {object} domain.ErrorResponse "Failed to logout"
+// @Router /api/auth/logout [post]
+func (h *AuthHandler) Logout(c *fiber.Ctx) error {
+	req := new(domain.RefreshTokenRequest)
+	if err := parseBody(c, req); err != nil {
+		return invalidInput(err)
+	}
+
+	if err := h.Usecase.Logout(req.RefreshToken); err != nil {
+		return fail(err, "Failed to logout")
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,226 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/isd-sgcu/cutu2025-backend/domain"
+	"github.com/isd-sgcu/cutu2025-backend/middleware"
+	"github.com/isd-sgcu/cutu2025-backend/usecase"
+)
+
+// CheckInHandler represents the handler for gate scanning and the check-in log
+type CheckInHandler struct {
+	Usecase *usecase.CheckInUsecase
+}
+
+// NewCheckInHandler creates a new CheckInHandler
+func NewCheckInHandler(usecase *usecase.CheckInUsecase) *CheckInHandler {
+	return &CheckInHandler{Usecase: usecase}
+}
+
+// Scan QR godoc
+// @Summary Scan QR code
+// @Description Check the user identified by a signed QR token in or out. Each token can only be scanned once and expires shortly after it is issued. Every attempt is recorded in the check-in log.
+// @Produce  json
+// @security BearerAuth
+// @Param token path string true "QR Token"
+// @Param gate query string false "Gate the scan was made at; ignored for staff assigned to a gate"
+// @Param deviceId query string false "Scanning device"
+// @Param direction query string false "Whether the user is entering or leaving" Enums(entry, exit) default(entry)
+// @Success 200 {object} domain.ScanResult
+// @Failure 400 {object} domain.ErrorResponse "Invalid direction"
+// @Failure 401 {object} domain.ErrorResponse "Unauthorized"
+// @Failure 403 {object} domain.ErrorResponse "Forbidden"
+// @Failure 404 {object} domain.ErrorResponse "Gate not found, or no gate was given while a zone restricts who may enter"
+// @Failure 409 {object} domain.ScanResult "QR code has already been scanned, user has already entered or is not inside, no session is open for entry, or the zone is full"
+// @Failure 422 {object} domain.ScanResult "Invalid or expired QR code, or the user is not permitted in this zone or is on the waitlist"
+// @Failure 500 {object} domain.ErrorResponse "Failed to scan QR"
+// @Router /api/users/qr/{token} [post]
+func (h *CheckInHandler) ScanQR(c *fiber.Ctx) error {
+	token := c.Params("token")
+	direction := domain.CheckInDirection(c.Query("direction", string(domain.DirectionEntry)))
+	if direction != domain.DirectionEntry && direction != domain.DirectionExit {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid direction")
+	}
+
+	principal, _ := middleware.GetPrincipal(c)
+	result, err := h.Usecase.ScanQR(token, domain.ScanContext{
+		ScannerID: principal.UserID,
+		Gate:      c.Query("gate"),
+		DeviceID:  c.Query("deviceId"),
+		Direction: direction,
+	})
+	if err != nil {
+		return scanError(c, err, result)
+	}
+	return c.Status(fiber.StatusOK).JSON(result)
+}
+
+// Lookup godoc
+// @Summary Look up an attendee
+// @Description Find an attendee by UID (e.g. AB2345678C) or phone when their QR code won't scan. Staff should compare the photo (imageUrl) with the attendee before checking them in manually.
+// @Produce  json
+// @security BearerAuth
+// @Param uid query string false "Attendee UID"
+// @Param phone query string false "Attendee phone number"
+// @Success 200 {object} domain.GateAttendee
+// @Failure 400 {object} domain.ErrorResponse "Either uid or phone is required, or the UID has a typo"
+// @Failure 404 {object} domain.ErrorResponse "User not found"
+// @Failure 500 {object} domain.ErrorResponse "Failed to fetch user"
+// @Router /api/checkins/lookup [get]
+func (h *CheckInHandler) Lookup(c *fiber.Ctx) error {
+	uid, phone := c.Query("uid"), c.Query("phone")
+	if uid == "" && phone == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "Either uid or phone is required")
+	}
+
+	user, err := h.Usecase.LookupAttendee(uid, phone)
+	if err != nil {
+		return fail(err, "Failed to fetch user")
+	}
+	return c.Status(fiber.StatusOK).JSON(user)
+}
+
+// ManualCheckIn godoc
+// @Summary Check in manually
+// @Description Check an attendee found with the lookup endpoint in or out without scanning their QR code. The same entry rules as scanning apply, and the check-in is logged with the manual source.
+// @Accept  json
+// @Produce  json
+// @security BearerAuth
+// @Param body body domain.ManualCheckInRequest true "Attendee and where they are being checked in"
+// @Success 200 {object} domain.ScanResult
+// @Failure 400 {object} domain.ErrorResponse "Invalid input"
+// @Failure 404 {object} domain.ErrorResponse "User or gate not found, or no gate was given while a zone restricts who may enter"
+// @Failure 409 {object} domain.ScanResult "User has already entered or is not inside, no session is open for entry, or the zone is full"
+// @Failure 422 {object} domain.ScanResult "User is not permitted in this zone or is on the waitlist"
+// @Failure 500 {object} domain.ErrorResponse "Failed to check in"
+// @Router /api/checkins/manual [post]
+func (h *CheckInHandler) ManualCheckIn(c *fiber.Ctx) error {
+	req := new(domain.ManualCheckInRequest)
+	if err := parseBody(c, req); err != nil {
+		return invalidInput(err)
+	}
+
+	principal, _ := middleware.GetPrincipal(c)
+	result, err := h.Usecase.ManualCheckIn(req.UserID, domain.ScanContext{
+		ScannerID: principal.UserID,
+		Gate:      req.Gate,
+		DeviceID:  req.DeviceID,
+		Direction: req.Direction,
+	})
+	if err != nil {
+		return scanError(c, err, result)
+	}
+	return c.Status(fiber.StatusOK).JSON(result)
+}
+
+// Void godoc
+// @Summary Void a check-in
+// @Description Revert a mistaken accepted check-in, e.g. when the wrong person was scanned. The user's last entry time and presence are restored from their remaining check-ins so they can be scanned again. Who voided it, when and why are recorded on the check-in.
+// @Accept  json
+// @Produce  json
+// @security BearerAuth
+// @Param id path string true "Check-in ID"
+// @Param body body domain.VoidCheckInRequest true "Why the check-in is being voided"
+// @Success 200 {object} domain.CheckIn
+// @Failure 400 {object} domain.ErrorResponse "A reason is required to void a check-in"
+// @Failure 404 {object} domain.ErrorResponse "Check-in not found"
+// @Failure 409 {object} domain.ErrorResponse "Check-in cannot be voided"
+// @Failure 500 {object} domain.ErrorResponse "Failed to void check-in"
+// @Router /api/checkins/{id}/void [post]
+func (h *CheckInHandler) Void(c *fiber.Ctx) error {
+	req := new(domain.VoidCheckInRequest)
+	if err := parseBody(c, req); err != nil {
+		return invalidInput(err)
+	}
+
+	principal, _ := middleware.GetPrincipal(c)
+	checkIn, err := h.Usecase.Void(c.Params("id"), principal.UserID, req.Reason)
+	if err != nil {
+		return fail(err, "Failed to void check-in")
+	}
+	return c.Status(fiber.StatusOK).JSON(checkIn)
+}
+
+// List godoc
+// @Summary List check-ins
+// @Description List scan attempts, newest first, filtered by user, scanner, gate, zone, direction, source, result and time range
+// @Produce  json
+// @security BearerAuth
+// @Param userId query string false "Filter by user ID"
+// @Param sessionId query string false "Filter by session ID"
+// @Param scannerId query string false "Filter by the staff member who scanned"
+// @Param gate query string false "Filter by gate"
+// @Param zoneId query string false "Filter by zone ID"
+// @Param direction query string false "Filter by direction" Enums(entry, exit)
+// @Param source query string false "Filter by source" Enums(online, offline, manual)
+// @Param result query string false "Filter by result" Enums(accepted, duplicate, rejected, conflict, voided)
+// @Param from query string false "Scanned at or after (RFC 3339)"
+// @Param to query string false "Scanned before (RFC 3339)"
+// @Param limit query int false "Maximum number of results (1-1000)" default(100)
+// @Param offset query int false "Number of results to skip" default(0)
+// @Success 200 {array} domain.CheckIn
+// @Failure 400 {object} domain.ErrorResponse "Invalid input"
+// @Failure 401 {object} domain.ErrorResponse "Unauthorized"
+// @Failure 403 {object} domain.ErrorResponse "Forbidden"
+// @Failure 500 {object} domain.ErrorResponse "Failed to fetch check-ins"
+// @Router /api/checkins [get]
+func (h *CheckInHandler) List(c *fiber.Ctx) error {
+	filter := domain.CheckInFilter{
+		UserID:    c.Query("userId"),
+		SessionID: c.Query("sessionId"),
+		ScannerID: c.Query("scannerId"),
+		Gate:      c.Query("gate"),
+		ZoneID:    c.Query("zoneId"),
+		Direction: domain.CheckInDirection(c.Query("direction")),
+		Source:    domain.CheckInSource(c.Query("source")),
+		Result:    domain.CheckInResult(c.Query("result")),
+		Limit:     c.QueryInt("limit", 100),
+		Offset:    c.QueryInt("offset", 0),
+	}
+	if filter.Limit < 1 || filter.Limit > 1000 || filter.Offset < 0 {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid input")
+	}
+
+	var err error
+	if filter.From, err = parseTimeQuery(c, "from"); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid from")
+	}
+	if filter.To, err = parseTimeQuery(c, "to"); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid to")
+	}
+
+	checkIns, err := h.Usecase.List(filter)
+	if err != nil {
+		return fail(err, "Failed to fetch check-ins")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(checkIns)
+}
+
+// scanError reports a scan that was not accepted. When the user is known the
+// scan result is returned, so staff can see who was turned away.
+func scanError(c *fiber.Ctx, err error, result domain.ScanResult) error {
+	err = fail(err, "Failed to scan QR")
+	if result.UserID == "" {
+		return err
+	}
+	status, response := middleware.ErrorResponse(err)
+	result.Error = response.Error
+	result.Code = response.Code
+	return c.Status(status).JSON(result)
+}
+
+// parseTimeQuery parses an optional RFC 3339 query parameter
+func parseTimeQuery(c *fiber.Ctx, key string) (*time.Time, error) {
+	value := c.Query(key)
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/isd-sgcu/cutu2025-backend/middleware"
+	"github.com/isd-sgcu/cutu2025-backend/usecase"
+)
+
+// dashboardStatsInterval is how often streamed dashboards receive fresh aggregates
+const dashboardStatsInterval = 5 * time.Second
+
+// DashboardHandler represents the handler for live check-in dashboards
+type DashboardHandler struct {
+	Usecase     *usecase.DashboardUsecase
+	AuthUsecase *usecase.AuthUsecase
+}
+
+// NewDashboardHandler creates a new DashboardHandler
+func NewDashboardHandler(usecase *usecase.DashboardUsecase, authUsecase *usecase.AuthUsecase) *DashboardHandler {
+	return &DashboardHandler{Usecase: usecase, AuthUsecase: authUsecase}
+}
+
+// GetStats godoc
+// @Summary Get check-in aggregates
+// @Description Accepted entries over the last hour: total, per minute, per gate and per status
+// @Produce  json
+// @security BearerAuth
+// @Success 200 {object} domain.DashboardStats
+// @Failure 500 {object} domain.ErrorResponse "Failed to fetch stats"
+// @Router /api/dashboard/stats [get]
+func (h *DashboardHandler) GetStats(c *fiber.Ctx) error {
+	stats, err := h.Usecase.GetStats()
+	if err != nil {
+		return fail(err, "Failed to fetch stats")
+	}
+	return c.Status(fiber.StatusOK).JSON(stats)
+}
+
+// IssueStreamToken godoc
+// @Summary Get a stream token
+// @Description Short-lived token for opening the check-in stream with EventSource, which cannot send an Authorization header. Pass it as the token query parameter; it is only checked when the stream is opened, so fetch a new one before reconnecting.
+// @Produce  json
+// @security BearerAuth
+// @Success 200 {object} domain.StreamTokenResponse
+// @Failure 401 {object} domain.ErrorResponse "Unauthorized"
+// @Failure 403 {object} domain.ErrorResponse "Forbidden"
+// @Failure 500 {object} domain.ErrorResponse "Failed to issue stream token"
+// @Router /api/dashboard/stream-token [post]
+func (h *DashboardHandler) IssueStreamToken(c *fiber.Ctx) error {
+	principal, _ := middleware.GetPrincipal(c)
+	token, err := h.AuthUsecase.IssueStreamToken(principal.UserID)
+	if err != nil {
+		return fail(err, "Failed to issue stream token")
+	}
+	return c.Status(fiber.StatusOK).JSON(token)
+}
+
+// Stream godoc
+// @Summary Stream check-ins
+// @Description Server-Sent Events stream. A "checkin" event (domain.CheckInEvent) is sent for every scan attempt, and a "stats" event (domain.DashboardStats) every 5 seconds. Authenticate with the Authorization header, or with a stream token in the token query parameter when using EventSource.
+// @Produce  text/event-stream
+// @security BearerAuth
+// @Param token query string false "Stream token from /api/dashboard/stream-token"
+// @Success 200 {string} string "Event stream"
+// @Failure 401 {object} domain.ErrorResponse "Unauthorized"
+// @Failure 403 {object} domain.ErrorResponse "Forbidden"
+// @Router /api/dashboard/stream [get]
+func (h *DashboardHandler) Stream(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no") // Stop proxies from buffering the stream
+
+	events, unsubscribe := h.Usecase.Subscribe()
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer unsubscribe()
+
+		ticker := time.NewTicker(dashboardStatsInterval)
+		defer ticker.Stop()
+
+		// A failed write means the dashboard has gone away
+		if h.writeStats(w) != nil {
+			return
+		}
+		for {
+			select {
+			case event, ok := <-events:
+				if !ok || writeEvent(w, "checkin", event) != nil {
+					return
+				}
+			case <-ticker.C:
+				if h.writeStats(w) != nil {
+					return
+				}
+			}
+		}
+	})
+	return nil
+}
+
+func (h *DashboardHandler) writeStats(w *bufio.Writer) error {
+	stats, err := h.Usecase.GetStats()
+	if err != nil {
+		// Keep the stream open; the next tick may succeed
+		_, err = fmt.Fprint(w, ": stats unavailable\n\n")
+		if err != nil {
+			return err
+		}
+		return w.Flush()
+	}
+	return writeEvent(w, "stats", stats)
+}
+
+// writeEvent writes a single Server-Sent Event and flushes it to the client
+func writeEvent(w *bufio.Writer, name string, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", name, payload); err != nil {
+		return err
+	}
+	return w.Flush()
+}
@@ -0,0 +1,21 @@
+package handler
+
+import (
+	"errors"
+	"log"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/isd-sgcu/cutu2025-backend/domain"
+)
+
+// fail passes err on to the app's error handler. Errors clients cannot act
+// on are logged and reported as a 500 with the fallback message.
+func fail(err error, fallback string) error {
+	var derr *domain.Error
+	var verr *domain.ValidationError
+	if errors.As(err, &derr) || errors.As(err, &verr) {
+		return err
+	}
+	log.Printf("%s: %v", fallback, err)
+	return fiber.NewError(fiber.StatusInternalServerError, fallback)
+}
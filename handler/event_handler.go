@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/isd-sgcu/cutu2025-backend/domain"
+	"github.com/isd-sgcu/cutu2025-backend/usecase"
+)
+
+// EventHandler represents the handler for events and their sessions
+type EventHandler struct {
+	Usecase *usecase.EventUsecase
+}
+
+// NewEventHandler creates a new EventHandler
+func NewEventHandler(usecase *usecase.EventUsecase) *EventHandler {
+	return &EventHandler{Usecase: usecase}
+}
+
+// Create godoc
+// @Summary Create an event
+// @Description Create an event; sessions are added separately
+// @Accept  json
+// @Produce  json
+// @security BearerAuth
+// @Param event body domain.Event true "Event data"
+// @Success 201 {object} domain.Event
+// @Failure 400 {object} domain.ErrorResponse "Invalid event"
+// @Failure 401 {object} domain.ErrorResponse "Unauthorized"
+// @Failure 403 {object} domain.ErrorResponse "Forbidden"
+// @Failure 500 {object} domain.ErrorResponse "Failed to create event"
+// @Router /api/events [post]
+func (h *EventHandler) Create(c *fiber.Ctx) error {
+	event := new(domain.Event)
+	if err := parseBody(c, event); err != nil {
+		return invalidInput(err)
+	}
+	if err := h.Usecase.Create(event); err != nil {
+		return fail(err, "Failed to create event")
+	}
+	return c.Status(fiber.StatusCreated).JSON(event)
+}
+
+// GetAll godoc
+// @Summary Get all events
+// @Description Retrieve all events with their sessions
+// @Produce  json
+// @security BearerAuth
+// @Success 200 {array} domain.Event
+// @Failure 500 {object} domain.ErrorResponse "Failed to fetch events"
+// @Router /api/events [get]
+func (h *EventHandler) GetAll(c *fiber.Ctx) error {
+	events, err := h.Usecase.GetAll()
+	if err != nil {
+		return fail(err, "Failed to fetch events")
+	}
+	return c.Status(fiber.StatusOK).JSON(events)
+}
+
+// GetById godoc
+// @Summary Get event by ID
+// @Description Retrieve an event with its sessions
+// @Produce  json
+// @security BearerAuth
+// @Param id path string true "Event ID"
+// @Success 200 {object} domain.Event
+// @Failure 404 {object} domain.ErrorResponse "Event not found"
+// @Failure 500 {object} domain.ErrorResponse "Failed to fetch event"
+// @Router /api/events/{id} [get]
+func (h *EventHandler) GetById(c *fiber.Ctx) error {
+	event, err := h.Usecase.GetById(c.Params("id"))
+	if err != nil {
+		return fail(err, "Failed to fetch event")
+	}
+	return c.Status(fiber.StatusOK).JSON(event)
+}
+
+// Delete godoc
+// @Summary Delete event by ID
+// @Description Delete an event and its sessions
+// @security BearerAuth
+// @Param id path string true "Event ID"
+// @Success 204
+// @Failure 404 {object} domain.ErrorResponse "Event not found"
+// @Failure 500 {object} domain.ErrorResponse "Failed to delete event"
+// @Router /api/events/{id} [delete]
+func (h *EventHandler) Delete(c *fiber.Ctx) error {
+	if err := h.Usecase.Delete(c.Params("id")); err != nil {
+		return fail(err, "Failed to delete event")
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// CreateSession godoc
+// @Summary Add a session to an event
+// @Description Add a session with its entry window and policy. Sessions may not overlap.
+// @Accept  json
+// @Produce  json
+// @security BearerAuth
+// @Param id path string true "Event ID"
+// @Param session body domain.CreateSessionRequest true "Session data"
+// @Success 201 {object} domain.Session
+// @Failure 400 {object} domain.ErrorResponse "Invalid session"
+// @Failure 404 {object} domain.ErrorResponse "Event not found"
+// @Failure 409 {object} domain.ErrorResponse "Session overlaps another session"
+// @Failure 500 {object} domain.ErrorResponse "Failed to create session"
+// @Router /api/events/{id}/sessions [post]
+func (h *EventHandler) CreateSession(c *fiber.Ctx) error {
+	req := new(domain.CreateSessionRequest)
+	if err := parseBody(c, req); err != nil {
+		return invalidInput(err)
+	}
+	session := req.Session()
+	if err := h.Usecase.CreateSession(c.Params("id"), session); err != nil {
+		return fail(err, "Failed to create session")
+	}
+	return c.Status(fiber.StatusCreated).JSON(session)
+}
+
+// UpdateSession godoc
+// @Summary Update session by ID
+// @Description Update a session's name, window or entry policy. Fields left out are not changed.
+// @Accept  json
+// @security BearerAuth
+// @Param id path string true "Session ID"
+// @Param session body domain.UpdateSessionRequest true "Fields to change"
+// @Success 204
+// @Failure 400 {object} domain.ErrorResponse "Invalid session"
+// @Failure 404 {object} domain.ErrorResponse "Session not found"
+// @Failure 409 {object} domain.ErrorResponse "Session overlaps another session"
+// @Failure 500 {object} domain.ErrorResponse "Failed to update session"
+// @Router /api/sessions/{id} [patch]
+func (h *EventHandler) UpdateSession(c *fiber.Ctx) error {
+	req := new(domain.UpdateSessionRequest)
+	if err := parseBody(c, req); err != nil {
+		return invalidInput(err)
+	}
+	if err := h.Usecase.UpdateSession(c.Params("id"), *req); err != nil {
+		return fail(err, "Failed to update session")
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// DeleteSession godoc
+// @Summary Delete session by ID
+// @security BearerAuth
+// @Param id path string true "Session ID"
+// @Success 204
+// @Failure 404 {object} domain.ErrorResponse "Session not found"
+// @Failure 500 {object} domain.ErrorResponse "Failed to delete session"
+// @Router /api/sessions/{id} [delete]
+func (h *EventHandler) DeleteSession(c *fiber.Ctx) error {
+	if err := h.Usecase.DeleteSession(c.Params("id")); err != nil {
+		return fail(err, "Failed to delete session")
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// GetActiveSession godoc
+// @Summary Get the active session
+// @Description Retrieve the session gates are currently admitting to
+// @Produce  json
+// @security BearerAuth
+// @Success 200 {object} domain.Session
+// @Failure 409 {object} domain.ErrorResponse "No session is open for entry"
+// @Failure 500 {object} domain.ErrorResponse "Failed to fetch session"
+// @Router /api/sessions/active [get]
+func (h *EventHandler) GetActiveSession(c *fiber.Ctx) error {
+	session, err := h.Usecase.GetActiveSession()
+	if err != nil {
+		return fail(err, "Failed to fetch session")
+	}
+	return c.Status(fiber.StatusOK).JSON(session)
+}
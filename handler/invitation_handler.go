@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/isd-sgcu/cutu2025-backend/domain"
+	"github.com/isd-sgcu/cutu2025-backend/middleware"
+	"github.com/isd-sgcu/cutu2025-backend/usecase"
+)
+
+// InvitationHandler represents the handler for invitation codes
+type InvitationHandler struct {
+	Usecase *usecase.InvitationUsecase
+}
+
+// NewInvitationHandler creates a new InvitationHandler
+func NewInvitationHandler(usecase *usecase.InvitationUsecase) *InvitationHandler {
+	return &InvitationHandler{Usecase: usecase}
+}
+
+// CreateBatch godoc
+// @Summary Generate invitation codes
+// @Description Generate a batch of codes that can each be redeemed maxUses times before expiresAt. Users who register with a code are given its status and ticket type, if set; zones admit ticket types through their allowlists.
+// @Accept  json
+// @Produce  json
+// @security BearerAuth
+// @Param batch body domain.CreateInvitationsRequest true "Batch data"
+// @Success 201 {array} domain.Invitation
+// @Failure 400 {object} domain.ErrorResponse "Invalid invitation"
+// @Failure 401 {object} domain.ErrorResponse "Unauthorized"
+// @Failure 403 {object} domain.ErrorResponse "Forbidden"
+// @Failure 500 {object} domain.ErrorResponse "Failed to create invitations"
+// @Router /api/invitations [post]
+func (h *InvitationHandler) CreateBatch(c *fiber.Ctx) error {
+	var req domain.CreateInvitationsRequest
+	if err := parseBody(c, &req); err != nil {
+		return invalidInput(err)
+	}
+
+	principal, _ := middleware.GetPrincipal(c)
+	invitations, err := h.Usecase.CreateBatch(req, principal.UserID)
+	if err != nil {
+		return fail(err, "Failed to create invitations")
+	}
+	return c.Status(fiber.StatusCreated).JSON(invitations)
+}
+
+// GetAll godoc
+// @Summary Get invitation codes
+// @Description List invitation codes with how often each has been used
+// @Produce  json
+// @security BearerAuth
+// @Param batchId query string false "Only codes from this batch"
+// @Success 200 {array} domain.Invitation
+// @Failure 500 {object} domain.ErrorResponse "Failed to fetch invitations"
+// @Router /api/invitations [get]
+func (h *InvitationHandler) GetAll(c *fiber.Ctx) error {
+	invitations, err := h.Usecase.GetAll(c.Query("batchId"))
+	if err != nil {
+		return fail(err, "Failed to fetch invitations")
+	}
+	return c.Status(fiber.StatusOK).JSON(invitations)
+}
+
+// GetRedemptions godoc
+// @Summary Get invitation redemptions
+// @Description List which users redeemed which code. Leave out the code to list redemptions of every code, optionally only from one batch.
+// @Produce  json
+// @security BearerAuth
+// @Param code path string false "Invitation code"
+// @Param batchId query string false "Only codes from this batch"
+// @Success 200 {array} domain.RedemptionDetail
+// @Failure 404 {object} domain.ErrorResponse "Invitation not found"
+// @Failure 500 {object} domain.ErrorResponse "Failed to fetch redemptions"
+// @Router /api/invitations/{code}/redemptions [get]
+// @Router /api/invitations/redemptions [get]
+func (h *InvitationHandler) GetRedemptions(c *fiber.Ctx) error {
+	redemptions, err := h.Usecase.GetRedemptions(c.Params("code"), c.Query("batchId"))
+	if err != nil {
+		return fail(err, "Failed to fetch redemptions")
+	}
+	return c.Status(fiber.StatusOK).JSON(redemptions)
+}
+
+// Revoke godoc
+// @Summary Revoke invitation code
+// @Description Stop a code from being redeemed. Users who already redeemed it keep what it granted.
+// @security BearerAuth
+// @Param code path string true "Invitation code"
+// @Success 204
+// @Failure 404 {object} domain.ErrorResponse "Invitation not found"
+// @Failure 409 {object} domain.ErrorResponse "Invitation has already been revoked"
+// @Failure 500 {object} domain.ErrorResponse "Failed to revoke invitation"
+// @Router /api/invitations/{code}/revoke [post]
+func (h *InvitationHandler) Revoke(c *fiber.Ctx) error {
+	if err := h.Usecase.Revoke(c.Params("code")); err != nil {
+		return fail(err, "Failed to revoke invitation")
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/isd-sgcu/cutu2025-backend/domain"
+	"github.com/isd-sgcu/cutu2025-backend/middleware"
+	"github.com/isd-sgcu/cutu2025-backend/usecase"
+)
+
+// RegistrationQuotaHandler represents the handler for registration quotas and the waitlist
+type RegistrationQuotaHandler struct {
+	Usecase *usecase.RegistrationQuotaUsecase
+}
+
+// NewRegistrationQuotaHandler creates a new RegistrationQuotaHandler
+func NewRegistrationQuotaHandler(usecase *usecase.RegistrationQuotaUsecase) *RegistrationQuotaHandler {
+	return &RegistrationQuotaHandler{Usecase: usecase}
+}
+
+// GetUsage godoc
+// @Summary Get registration quotas
+// @Description List each status's quota with how many users are confirmed and waitlisted. Statuses with users but no quota are listed with a null capacity.
+// @Produce  json
+// @security BearerAuth
+// @Success 200 {array} domain.QuotaUsage
+// @Failure 500 {object} domain.ErrorResponse "Failed to fetch quotas"
+// @Router /api/quotas [get]
+func (h *RegistrationQuotaHandler) GetUsage(c *fiber.Ctx) error {
+	usage, err := h.Usecase.GetUsage()
+	if err != nil {
+		return fail(err, "Failed to fetch quotas")
+	}
+	return c.Status(fiber.StatusOK).JSON(usage)
+}
+
+// Set godoc
+// @Summary Set registration quota
+// @Description Cap how many users of a status get a place. Users who register once it is full are waitlisted. Raising the capacity promotes waitlisted users, earliest first; lowering it does not remove anyone.
+// @Accept  json
+// @Produce  json
+// @security BearerAuth
+// @Param status path domain.Status true "User status"
+// @Param quota body domain.RegistrationQuota true "Quota data"
+// @Success 200 {object} domain.RegistrationQuota
+// @Failure 400 {object} domain.ErrorResponse "Invalid quota"
+// @Failure 500 {object} domain.ErrorResponse "Failed to set quota"
+// @Router /api/quotas/{status} [put]
+func (h *RegistrationQuotaHandler) Set(c *fiber.Ctx) error {
+	quota := new(domain.RegistrationQuota)
+	if err := parseBody(c, quota); err != nil {
+		return invalidInput(err)
+	}
+	quota.Status = domain.Status(c.Params("status"))
+
+	if err := h.Usecase.Set(quota); err != nil {
+		return fail(err, "Failed to set quota")
+	}
+	return c.Status(fiber.StatusOK).JSON(quota)
+}
+
+// Delete godoc
+// @Summary Delete registration quota
+// @Description Make a status unlimited again. Everyone on its waitlist is confirmed.
+// @security BearerAuth
+// @Param status path domain.Status true "User status"
+// @Success 204
+// @Failure 404 {object} domain.ErrorResponse "Quota not found"
+// @Failure 500 {object} domain.ErrorResponse "Failed to delete quota"
+// @Router /api/quotas/{status} [delete]
+func (h *RegistrationQuotaHandler) Delete(c *fiber.Ctx) error {
+	if err := h.Usecase.Delete(domain.Status(c.Params("status"))); err != nil {
+		return fail(err, "Failed to delete quota")
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// GetMyPosition godoc
+// @Summary Get my waitlist position
+// @Description See whether you have a place at the event and, if you are waitlisted, how many people are ahead of you. Position 1 is next in line.
+// @Produce  json
+// @security BearerAuth
+// @Success 200 {object} domain.WaitlistPosition
+// @Failure 401 {object} domain.ErrorResponse "Unauthorized"
+// @Failure 404 {object} domain.ErrorResponse "User not found"
+// @Failure 500 {object} domain.ErrorResponse "Failed to fetch waitlist position"
+// @Router /api/waitlist/me [get]
+func (h *RegistrationQuotaHandler) GetMyPosition(c *fiber.Ctx) error {
+	principal, ok := middleware.GetPrincipal(c)
+	if !ok {
+		return fiber.NewError(fiber.StatusUnauthorized, "Unauthorized")
+	}
+
+	position, err := h.Usecase.GetWaitlistPosition(principal.UserID)
+	if err != nil {
+		return fail(err, "Failed to fetch waitlist position")
+	}
+	return c.Status(fiber.StatusOK).JSON(position)
+}
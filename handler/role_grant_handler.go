@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/isd-sgcu/cutu2025-backend/domain"
+	"github.com/isd-sgcu/cutu2025-backend/middleware"
+	"github.com/isd-sgcu/cutu2025-backend/usecase"
+)
+
+// RoleGrantHandler represents the handler for the role allowlist
+type RoleGrantHandler struct {
+	Usecase *usecase.RoleGrantUsecase
+}
+
+// NewRoleGrantHandler creates a new RoleGrantHandler
+func NewRoleGrantHandler(usecase *usecase.RoleGrantUsecase) *RoleGrantHandler {
+	return &RoleGrantHandler{Usecase: usecase}
+}
+
+// Create godoc
+// @Summary Grant a role
+// @Description Give a role to whoever registers with a LIFF ID. A user who has already registered with it is given the role straight away. Granting again to the same LIFF ID changes the role.
+// @Accept  json
+// @Produce  json
+// @security BearerAuth
+// @Param grant body domain.RoleGrant true "Grant data"
+// @Success 201 {object} domain.RoleGrant
+// @Failure 400 {object} domain.ErrorResponse "Invalid role grant"
+// @Failure 401 {object} domain.ErrorResponse "Unauthorized"
+// @Failure 403 {object} domain.ErrorResponse "Forbidden"
+// @Failure 500 {object} domain.ErrorResponse "Failed to grant role"
+// @Router /api/roles/grants [post]
+func (h *RoleGrantHandler) Create(c *fiber.Ctx) error {
+	grant := new(domain.RoleGrant)
+	if err := parseBody(c, grant); err != nil {
+		return invalidInput(err)
+	}
+
+	principal, _ := middleware.GetPrincipal(c)
+	if err := h.Usecase.Create(grant, principal.UserID); err != nil {
+		return fail(err, "Failed to grant role")
+	}
+	return c.Status(fiber.StatusCreated).JSON(grant)
+}
+
+// GetAll godoc
+// @Summary Get all role grants
+// @Produce  json
+// @security BearerAuth
+// @Success 200 {array} domain.RoleGrant
+// @Failure 500 {object} domain.ErrorResponse "Failed to fetch role grants"
+// @Router /api/roles/grants [get]
+func (h *RoleGrantHandler) GetAll(c *fiber.Ctx) error {
+	grants, err := h.Usecase.GetAll()
+	if err != nil {
+		return fail(err, "Failed to fetch role grants")
+	}
+	return c.Status(fiber.StatusOK).JSON(grants)
+}
+
+// Delete godoc
+// @Summary Delete role grant by ID
+// @Description Stop granting the role to new registrations. Users who already hold the role keep it; change it with the update role endpoint.
+// @security BearerAuth
+// @Param id path string true "Role grant ID"
+// @Success 204
+// @Failure 404 {object} domain.ErrorResponse "Role grant not found"
+// @Failure 500 {object} domain.ErrorResponse "Failed to delete role grant"
+// @Router /api/roles/grants/{id} [delete]
+func (h *RoleGrantHandler) Delete(c *fiber.Ctx) error {
+	if err := h.Usecase.Delete(c.Params("id")); err != nil {
+		return fail(err, "Failed to delete role grant")
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/isd-sgcu/cutu2025-backend/domain"
+	"github.com/isd-sgcu/cutu2025-backend/middleware"
+	"github.com/isd-sgcu/cutu2025-backend/usecase"
+)
+
+// ScannerHandler represents the handler for offline-capable gate devices
+type ScannerHandler struct {
+	Usecase *usecase.ScannerUsecase
+}
+
+// NewScannerHandler creates a new ScannerHandler
+func NewScannerHandler(usecase *usecase.ScannerUsecase) *ScannerHandler {
+	return &ScannerHandler{Usecase: usecase}
+}
+
+// GetKey godoc
+// @Summary Get snapshot signing key
+// @Description Public key devices use to verify attendee snapshots
+// @Produce  json
+// @security BearerAuth
+// @Success 200 {object} domain.SnapshotKey
+// @Router /api/scanner/key [get]
+func (h *ScannerHandler) GetKey(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusOK).JSON(h.Usecase.GetKey())
+}
+
+// Snapshot godoc
+// @Summary Download attendee snapshot
+// @Description Signed list of attendees the scanner may admit for a session, so it can keep scanning offline. Pass the cursor from the previous snapshot to fetch only what has changed since; if the session or zone rules have changed, a full snapshot is returned instead.
+// @Produce  json
+// @security BearerAuth
+// @Param sessionId query string true "Session ID"
+// @Param cursor query string false "Cursor from the previous snapshot"
+// @Success 200 {object} domain.SignedSnapshot
+// @Failure 400 {object} domain.ErrorResponse "Invalid cursor"
+// @Failure 404 {object} domain.ErrorResponse "Session not found"
+// @Failure 500 {object} domain.ErrorResponse "Failed to build snapshot"
+// @Router /api/scanner/snapshot [get]
+func (h *ScannerHandler) Snapshot(c *fiber.Ctx) error {
+	principal, _ := middleware.GetPrincipal(c)
+	snapshot, err := h.Usecase.Snapshot(c.Query("sessionId"), principal.UserID, c.Query("cursor"))
+	if err != nil {
+		return fail(err, "Failed to build snapshot")
+	}
+	return c.Status(fiber.StatusOK).JSON(snapshot)
+}
+
+// Sync godoc
+// @Summary Upload offline scans
+// @Description Reconcile scans a device made while offline. Scans the device admitted must carry the QR token it scanned, which must have been valid at scannedAt. Each scan is decided as if it had been made online at its scannedAt. Scans the device let through that the server would have refused are recorded as conflicts. Uploading the same scan ID again returns its recorded outcome.
+// @Accept  json
+// @Produce  json
+// @security BearerAuth
+// @Param scans body domain.SyncRequest true "Offline scans (at most 500)"
+// @Success 200 {object} domain.SyncResponse
+// @Failure 400 {object} domain.ErrorResponse "Invalid input"
+// @Failure 500 {object} domain.ErrorResponse "Failed to sync scans"
+// @Router /api/scanner/sync [post]
+func (h *ScannerHandler) Sync(c *fiber.Ctx) error {
+	req := new(domain.SyncRequest)
+	if err := parseBody(c, req); err != nil {
+		return invalidInput(err)
+	}
+
+	principal, _ := middleware.GetPrincipal(c)
+	response, err := h.Usecase.Sync(principal.UserID, *req)
+	if err != nil {
+		return fail(err, "Failed to sync scans")
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
@@ -1,14 +1,16 @@
 package handler
 
 import (
-	"errors"
+	"bufio"
+	"fmt"
 	"io"
-	"strings"
+	"log"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/isd-sgcu/cutu2025-backend/domain"
+	"github.com/isd-sgcu/cutu2025-backend/middleware"
 	"github.com/isd-sgcu/cutu2025-backend/usecase"
-	"github.com/isd-sgcu/cutu2025-backend/utils"
 )
 
 // UserHandler represents the handler for user-related endpoints
@@ -26,32 +28,38 @@ func NewUserHandler(usecase *usecase.UserUsecase) *UserHandler {
 // @Description Register a new user in the system
 // @Accept  multipart/form-data
 // @Produce  json
-// @Param id formData string true "User ID"
+// @Param idToken formData string true "LINE ID Token"
 // @Param name formData string true "User Name"
 // @Param email formData string false "User Email"
 // @Param phone formData string true "User Phone"
 // @Param university formData string false "User University"
-// @Param sizeJersey formData string false "Jersey Size"
+// @Param sizeJersey formData domain.JerseySize false "Jersey Size"
 // @Param foodLimitation formData string false "Food Limitation"
-// @Param invitationCode formData string false "Invitation Code"
+// @Param invitationCode formData string false "Invitation code, which is consumed and may grant a status or ticket type"
 // @Param status formData domain.Status true "User Status"
 // @Param image formData file false "User Image"
 // @Param age formData string false "User Age"
 // @Param chronicDisease formData string false "Chronic Disease"
 // @Param drugAllergy formData string false "Drug Allergy"
-// @Param graduatedYear formData string false "Graduated Year"
-// @Param faculty formData string false "Faculty"
+// @Param graduatedYear formData string false "Graduated year, required for alumni"
+// @Param faculty formData string false "Faculty, required for chula_student"
 // @Param isAcroPhobia formData bool true "Is Acrophobia"
 // @Param education formData domain.Education false "Education"
 // @Success 201 {object} domain.TokenResponse
-// @Failure 400 {object} domain.ErrorResponse "Invalid input"
+// @Failure 400 {object} domain.ErrorResponse "Invalid input, with every invalid field listed, or invalid invitation code"
 // @Failure 401 {object} domain.ErrorResponse "Unauthorized"
+// @Failure 409 {object} domain.ErrorResponse "Phone number is already registered"
 // @Failure 500 {object} domain.ErrorResponse "Failed to create user"
 // @Router /api/users/register [post]
 func (h *UserHandler) Register(c *fiber.Ctx) error {
 	form, err := c.MultipartForm()
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(domain.ErrorResponse{Error: "Invalid input"})
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid input")
+	}
+
+	req := new(domain.RegisterRequest)
+	if err := parseBody(c, req); err != nil {
+		return invalidInput(err)
 	}
 
 	var fileBytes []byte
@@ -60,92 +68,128 @@ func (h *UserHandler) Register(c *fiber.Ctx) error {
 		imageFile := imageFiles[0]
 		file, err := imageFile.Open()
 		if err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(domain.ErrorResponse{Error: "Failed to open image file"})
+			return fail(err, "Failed to open image file")
 		}
 		defer file.Close()
 
 		fileBytes, err = io.ReadAll(file)
 		if err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(domain.ErrorResponse{Error: "Failed to read image file"})
-		}
-	}
-
-	// Helper function to get required form values
-	getFormValue := func(key string) (string, bool) {
-		if v, ok := form.Value[key]; ok && len(v) > 0 {
-			return v[0], true
+			return fail(err, "Failed to read image file")
 		}
-		return "", false
 	}
 
-	// Helper function for optional values
-	getOptionalValue := func(key string) *string {
-		if v, ok := form.Value[key]; ok && len(v) > 0 {
-			return &v[0]
-		}
-		return nil
-	}
-
-	// Validate required fields
-	requiredFields := []string{"id", "name", "phone", "foodLimitation"}
-	userData := make(map[string]string)
-	for _, field := range requiredFields {
-		if value, ok := getFormValue(field); ok {
-			userData[field] = value
-		} else {
-			return c.Status(fiber.StatusBadRequest).JSON(domain.ErrorResponse{Error: field + " is required"})
-		}
-	}
-
-	user := &domain.User{
-		ID:             userData["id"],
-		Name:           userData["name"],
-		Email:          getOptionalValue("email"),
-		Phone:          userData["phone"],
-		University:     getOptionalValue("university"),
-		SizeJersey:     getOptionalValue("sizeJersey"),
-		FoodLimitation: userData["foodLimitation"],
-		Education:      func() *domain.Education { e := domain.Education(userData["education"]); return &e }(),
-		InvitationCode: getOptionalValue("invitationCode"),
-		Status:         domain.StatusAlumni,
-		GraduatedYear:  getOptionalValue("graduatedYear"),
-		Faculty:        getOptionalValue("faculty"),
-		Age:            getOptionalValue("age"),
-		ChronicDisease: getOptionalValue("chronicDisease"),
-		DrugAllergy:    getOptionalValue("drugAllergy"),
-		IsAcroPhobia: func() *bool {
-			b := form.Value["isAcroPhobia"] != nil && form.Value["isAcroPhobia"][0] == "true"
-			return &b
-		}(),
-	}
-
-	tokenResponse, err := h.Usecase.Register(user, fileBytes)
+	tokenResponse, err := h.Usecase.Register(req.IDToken, req.User(), fileBytes)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(domain.ErrorResponse{Error: "Failed to create user"})
+		return fail(err, "Failed to create user")
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(tokenResponse)
 }
 
 // GetAll godoc
-// @Summary Get all users
-// @Description Retrieve a list of all users with optional filtering
+// @Summary List users
+// @Description List a page of users, filtered and sorted. Filters combine; the total counts every user matching them.
 // @Produce  json
-// @Param name query string false "Filter by name"
 // @security BearerAuth
-// @Success 200 {array} domain.User
+// @Param search query string false "Part of a name, phone number, UID or email"
+// @Param status query string false "Filter by status" Enums(chula_student, alumni, general_public, general_student)
+// @Param role query string false "Filter by role" Enums(member, staff, admin)
+// @Param registration query string false "Filter by registration" Enums(confirmed, waitlisted)
+// @Param university query string false "Filter by university, ignoring case"
+// @Param faculty query string false "Filter by faculty, ignoring case"
+// @Param sizeJersey query string false "Filter by jersey size" Enums(XS, S, M, L, XL, 2XL, 3XL)
+// @Param checkedIn query bool false "Whether the user has entered the event"
+// @Param registeredFrom query string false "Registered at or after (RFC 3339)"
+// @Param registeredTo query string false "Registered before (RFC 3339)"
+// @Param sort query string false "Field to sort by, prefixed with - for descending order" Enums(registeredAt, -registeredAt, updatedAt, -updatedAt, lastEntered, -lastEntered, name, -name, uid, -uid) default(-registeredAt)
+// @Param limit query int false "Maximum number of results (1-1000)" default(100)
+// @Param offset query int false "Number of results to skip" default(0)
+// @Success 200 {object} domain.UserPage
+// @Failure 400 {object} domain.ErrorResponse "Invalid input"
+// @Failure 401 {object} domain.ErrorResponse "Unauthorized"
+// @Failure 403 {object} domain.ErrorResponse "Forbidden"
 // @Failure 500 {object} domain.ErrorResponse "Failed to fetch users"
 // @Router /api/users [get]
 func (h *UserHandler) GetAll(c *fiber.Ctx) error {
-	// Get query parameters
-	filter := c.Query("name")
+	query := domain.NewUserListQuery()
+	if err := parseUserFilter(c, &query, &query.UserFilter); err != nil {
+		return err
+	}
+
+	page, err := h.Usecase.List(query)
+	if err != nil {
+		return fail(err, "Failed to fetch users")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(page)
+}
 
-	users, err := h.Usecase.GetAll(filter)
+// parseUserFilter parses the query string into req, which embeds filter
+func parseUserFilter(c *fiber.Ctx, req any, filter *domain.UserFilter) error {
+	if err := parseQuery(c, req); err != nil {
+		return invalidInput(err)
+	}
+
+	var err error
+	if filter.RegisteredFrom, err = parseTimeQuery(c, "registeredFrom"); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid registeredFrom")
+	}
+	if filter.RegisteredTo, err = parseTimeQuery(c, "registeredTo"); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid registeredTo")
+	}
+	return nil
+}
+
+// exportContentTypes are the media types of each export format
+var exportContentTypes = map[domain.ExportFormat]string{
+	domain.ExportCSV:  "text/csv; charset=utf-8",
+	domain.ExportXLSX: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// Export godoc
+// @Summary Export users
+// @Description Download the users matching the filters as a CSV file or Excel workbook, in registration order. CSV files start with a UTF-8 byte order mark so Excel shows Thai text correctly. CSV is streamed as it is read from the database; an Excel workbook is assembled on the server (on disk past 16 MB) and only starts downloading once every row has been read, so prefer CSV for very large exports.
+// @Produce  text/csv
+// @Produce  application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @security BearerAuth
+// @Param format query string false "File format" Enums(csv, xlsx) default(csv)
+// @Param columns query string false "Comma-separated columns to include, in order, e.g. name,phone,sizeJersey; every column by default"
+// @Param search query string false "Part of a name, phone number, UID or email"
+// @Param status query string false "Filter by status" Enums(chula_student, alumni, general_public, general_student)
+// @Param role query string false "Filter by role" Enums(member, staff, admin)
+// @Param registration query string false "Filter by registration" Enums(confirmed, waitlisted)
+// @Param university query string false "Filter by university, ignoring case"
+// @Param faculty query string false "Filter by faculty, ignoring case"
+// @Param sizeJersey query string false "Filter by jersey size" Enums(XS, S, M, L, XL, 2XL, 3XL)
+// @Param checkedIn query bool false "Whether the user has entered the event"
+// @Param registeredFrom query string false "Registered at or after (RFC 3339)"
+// @Param registeredTo query string false "Registered before (RFC 3339)"
+// @Success 200 {file} file
+// @Failure 400 {object} domain.ErrorResponse "Invalid input"
+// @Failure 401 {object} domain.ErrorResponse "Unauthorized"
+// @Failure 403 {object} domain.ErrorResponse "Forbidden"
+// @Failure 500 {object} domain.ErrorResponse "Failed to export users"
+// @Router /api/users/export [get]
+func (h *UserHandler) Export(c *fiber.Ctx) error {
+	query := domain.NewUserExportQuery()
+	if err := parseUserFilter(c, &query, &query.UserFilter); err != nil {
+		return err
+	}
+
+	write, err := h.Usecase.Export(query)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(domain.ErrorResponse{Error: "Failed to fetch users"})
+		return fail(err, "Failed to export users")
 	}
 
-	return c.Status(fiber.StatusOK).JSON(users)
+	c.Attachment(fmt.Sprintf("attendees-%s.%s", time.Now().Format("20060102-150405"), query.Format))
+	c.Set(fiber.HeaderContentType, exportContentTypes[query.Format])
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		// The status has been sent by now, so a failure can only cut the file short
+		if err := write(w); err != nil {
+			log.Printf("Failed to export users: %v", err)
+		}
+	})
+	return nil
 }
 
 // GetById godoc
@@ -162,65 +206,43 @@ func (h *UserHandler) GetById(c *fiber.Ctx) error {
 	id := c.Params("id")
 	user, err := h.Usecase.GetById(id)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(domain.ErrorResponse{Error: "User not found"})
+		return fail(err, "Failed to fetch user")
 	}
 	return c.Status(fiber.StatusOK).JSON(user)
 }
 
 // Update godoc
 // @Summary Update user by ID
-// @Description Update a user by its ID
+// @Description Change a user's profile, status or ticket type. Only the fields sent are changed. Roles are changed with the update role and add staff endpoints.
 // @Accept  json
 // @Produce  json
 // @security BearerAuth
 // @Param id path string true "User ID"
-// @Param user body domain.User true "User data"
+// @Param user body domain.AdminUpdateUserRequest true "Fields to change; send \"\" to clear an optional field"
 // @Success 204
 // @Failure 400 {object} domain.ErrorResponse "Invalid input"
 // @Failure 401 {object} domain.ErrorResponse "Unauthorized"
 // @Failure 403 {object} domain.ErrorResponse "Forbidden"
 // @Failure 404 {object} domain.ErrorResponse "User not found"
+// @Failure 409 {object} domain.ErrorResponse "Phone number is already registered"
 // @Failure 500 {object} domain.ErrorResponse "Failed to update user"
 // @Router /api/users/{id} [patch]
 func (h *UserHandler) Update(c *fiber.Ctx) error {
 	id := c.Params("id")
-	user := new(domain.User)
-	if err := c.BodyParser(user); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(domain.ErrorResponse{Error: "Invalid input"})
+	req := new(domain.AdminUpdateUserRequest)
+	if err := parseBody(c, req); err != nil {
+		return invalidInput(err)
 	}
-	if err := h.Usecase.Update(id, user); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(domain.ErrorResponse{Error: "Failed to update user"})
+	if err := h.Usecase.AdminUpdate(id, *req); err != nil {
+		return fail(err, "Failed to update user")
 	}
 
 	return c.SendStatus(fiber.StatusNoContent)
 }
 
-// Scan QR godoc
-// @Summary Scan QR code
-// @Description Retrieve a user by its ID
-// @Produce  json
-// @security BearerAuth
-// @Param id path string true "User ID"
-// @Success 200 {object} domain.User
-// @Failure 500 {object} domain.ErrorResponse "Failed to fetch User"
-// @Failure 400 {object} domain.ErrorResponse "User has already entered"
-// @Router /api/users/qr/{id} [post]
-func (h *UserHandler) ScanQR(c *fiber.Ctx) error {
-	id := c.Params("id")
-	user, err := h.Usecase.ScanQR(id)
-	if err != nil {
-		if errors.Is(err, domain.ErrUserAlreadyEntered) {
-			t := user.LastEntered.String()
-			return c.Status(fiber.StatusBadRequest).JSON(domain.ErrorResponse{Error: "User has already entered", Message: &t})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(domain.ErrorResponse{Error: "Failed to scan QR"})
-	}
-	return c.Status(fiber.StatusOK).JSON(user)
-}
-
 // Change Role godoc
 // @Summary Update user role by ID
-// @Description Update a user by its ID
+// @Description Change a user's role. The user is signed out so their sessions pick up the new role.
 // @Accept  json
 // @security BearerAuth
 // @Produce  json
@@ -237,11 +259,11 @@ func (h *UserHandler) ScanQR(c *fiber.Ctx) error {
 func (h *UserHandler) UpdateRole(c *fiber.Ctx) error {
 	id := c.Params("id")
 	role := new(domain.Role)
-	if err := c.BodyParser(role); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(domain.ErrorResponse{Error: "Invalid input"})
+	if err := c.BodyParser(role); err != nil || !role.IsValid() {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid input")
 	}
 	if err := h.Usecase.UpdateRole(id, *role); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(domain.ErrorResponse{Error: "Failed to update this role user"})
+		return fail(err, "Failed to update this role user")
 	}
 
 	return c.SendStatus(fiber.StatusNoContent)
@@ -249,35 +271,31 @@ func (h *UserHandler) UpdateRole(c *fiber.Ctx) error {
 
 // Update account info godoc
 // @Summary Update Account Info
-// @Description Update a user by its ID
+// @Description Change your own profile. Only the fields sent are changed; your role, status, ticket type and UID cannot be changed here.
 // @Accept  json
 // @Produce  json
 // @security BearerAuth
-// @Param user body domain.User true "User data"
+// @Param user body domain.UpdateProfileRequest true "Fields to change; send \"\" to clear an optional field"
 // @Success 204
 // @Failure 400 {object} domain.ErrorResponse "Invalid input"
 // @Failure 401 {object} domain.ErrorResponse "Unauthorized"
 // @Failure 403 {object} domain.ErrorResponse "Forbidden"
 // @Failure 404 {object} domain.ErrorResponse "User not found"
+// @Failure 409 {object} domain.ErrorResponse "Phone number is already registered"
 // @Failure 500 {object} domain.ErrorResponse "Failed to update user role"
 // @Router /api/users [patch]
 func (h *UserHandler) UpdateMyAccountInfo(c *fiber.Ctx) error {
-	user := new(domain.User)
-	tokenHeader := c.Get("Authorization")
-	if !strings.HasPrefix(tokenHeader, "Bearer ") {
-		return c.Status(fiber.StatusUnauthorized).JSON(domain.ErrorResponse{Error: "Unauthorized"})
+	req := new(domain.UpdateProfileRequest)
+	principal, ok := middleware.GetPrincipal(c)
+	if !ok {
+		return fiber.NewError(fiber.StatusUnauthorized, "Unauthorized")
 	}
-	token := strings.TrimPrefix(tokenHeader, "Bearer ")
 
-	id, err := utils.DecodeToken(token, utils.GetEnv("SECRET_JWT_KEY", ""))
-	if err != nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(domain.ErrorResponse{Error: "Unauthorized " + err.Error()})
-	}
-	if err := c.BodyParser(user); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(domain.ErrorResponse{Error: "Invalid input"})
+	if err := parseBody(c, req); err != nil {
+		return invalidInput(err)
 	}
-	if err := h.Usecase.Update(id, user); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(domain.ErrorResponse{Error: "Failed to update this role user"})
+	if err := h.Usecase.UpdateProfile(principal.UserID, *req); err != nil {
+		return fail(err, "Failed to update this role user")
 	}
 
 	return c.SendStatus(fiber.StatusNoContent)
@@ -285,26 +303,87 @@ func (h *UserHandler) UpdateMyAccountInfo(c *fiber.Ctx) error {
 
 // GetQRURL godoc
 // @Summary Get QR code URL
-// @Description Retrieve a QR code URL for a user
+// @Description Issue a short-lived signed QR code to the attendee it identifies; no one else may mint a code for them, so staff admit attendees without one through manual check-in, which is audited. The app should fetch a new one every refreshAfter seconds.
 // @Produce  json
 // @security BearerAuth
 // @Param id path string true "User ID"
 // @Success 200 {object} domain.QrResponse
+// @Failure 403 {object} domain.ErrorResponse "Forbidden"
 // @Failure 404 {object} domain.ErrorResponse "User not found"
 // @Failure 500 {object} domain.ErrorResponse "Failed to fetch user"
 // @Router /api/users/qr/{id} [get]
 func (h *UserHandler) GetQRURL(c *fiber.Ctx) error {
 	id := c.Params("id")
-	qrURL, err := h.Usecase.GetQRURL(id)
+	if !isSelf(c, id) {
+		return fiber.NewError(fiber.StatusForbidden, "Forbidden")
+	}
+
+	qr, err := h.Usecase.GetQRCode(id)
+	if err != nil {
+		return fail(err, "Failed to fetch user")
+	}
+	return c.Status(fiber.StatusOK).JSON(qr)
+}
+
+// GetQRImage godoc
+// @Summary Get QR code image
+// @Description Render the caller's current QR code as PNG or SVG. Only the attendee the code identifies may fetch it. Responses carry an ETag that stays the same until a new code is issued.
+// @Produce  image/png
+// @Produce  image/svg+xml
+// @security BearerAuth
+// @Param id path string true "User ID"
+// @Param format query string false "Image format" Enums(png, svg) default(png)
+// @Param size query int false "Width and height in pixels (64-2048)" default(512)
+// @Param level query string false "Error-correction level" Enums(low, medium, high, highest) default(medium)
+// @Param logo query bool false "Draw the logo in the centre" default(false)
+// @Success 200 {file} file
+// @Success 304
+// @Failure 400 {object} domain.ErrorResponse "Invalid QR image options"
+// @Failure 403 {object} domain.ErrorResponse "Forbidden"
+// @Failure 404 {object} domain.ErrorResponse "User not found"
+// @Failure 500 {object} domain.ErrorResponse "Failed to render QR code"
+// @Router /api/users/qr/{id}/image [get]
+func (h *UserHandler) GetQRImage(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if !isSelf(c, id) {
+		return fiber.NewError(fiber.StatusForbidden, "Forbidden")
+	}
+
+	opts := domain.QrImageOptions{
+		Format: domain.QrImageFormat(c.Query("format", string(domain.QrImagePNG))),
+		Size:   c.QueryInt("size", 512),
+		Level:  c.Query("level", "medium"),
+		Logo:   c.QueryBool("logo", false),
+	}
+
+	// Fresh compares the ETag with If-None-Match, which may list several
+	// ETags or weak ones
+	img, err := h.Usecase.GetQRImage(id, opts, func(etag string) bool {
+		c.Set(fiber.HeaderETag, etag)
+		return c.Fresh()
+	})
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(domain.ErrorResponse{Error: "User not found"})
+		return fail(err, "Failed to render QR code")
 	}
-	return c.Status(fiber.StatusOK).JSON(domain.QrResponse{QrURL: qrURL})
+
+	c.Set(fiber.HeaderCacheControl, fmt.Sprintf("private, max-age=%d", img.RefreshAfter))
+	if img.NotModified {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
+	c.Set(fiber.HeaderContentType, img.ContentType)
+	return c.Status(fiber.StatusOK).Send(img.Data)
+}
+
+// isSelf reports whether the caller is the user identified by id
+func isSelf(c *fiber.Ctx, id string) bool {
+	principal, ok := middleware.GetPrincipal(c)
+	return ok && principal.UserID == id
 }
 
 // Delete godoc
 // @Summary Delete user by ID
-// @Description Delete a user by its ID
+// @Description Delete a user by its ID. If they had a place at the event it goes to the first person on the waitlist for their status.
 // @Produce  json
 // @security BearerAuth
 // @Param id path string true "User ID"
@@ -317,30 +396,52 @@ func (h *UserHandler) GetQRURL(c *fiber.Ctx) error {
 func (h *UserHandler) Delete(c *fiber.Ctx) error {
 	id := c.Params("id")
 	if err := h.Usecase.Delete(id); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(domain.ErrorResponse{Error: "Failed to delete user"})
+		return fail(err, "Failed to delete user")
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// CancelMyRegistration godoc
+// @Summary Cancel my registration
+// @Description Delete your own account. Your place at the event goes to the first person on the waitlist for your status.
+// @security BearerAuth
+// @Success 204
+// @Failure 401 {object} domain.ErrorResponse "Unauthorized"
+// @Failure 404 {object} domain.ErrorResponse "User not found"
+// @Failure 500 {object} domain.ErrorResponse "Failed to cancel registration"
+// @Router /api/users [delete]
+func (h *UserHandler) CancelMyRegistration(c *fiber.Ctx) error {
+	principal, ok := middleware.GetPrincipal(c)
+	if !ok {
+		return fiber.NewError(fiber.StatusUnauthorized, "Unauthorized")
+	}
+
+	if err := h.Usecase.Delete(principal.UserID); err != nil {
+		return fail(err, "Failed to cancel registration")
 	}
 	return c.SendStatus(fiber.StatusNoContent)
 }
 
 // SignIn godoc
 // @Summary SignIn
-// @Description SignIn
+// @Description SignIn with a LINE ID token obtained from LIFF
+// @Accept  json
 // @Produce  json
-// @Param id body string true "User ID"
+// @Param body body domain.SignInRequest true "LINE ID Token"
 // @Success 200 {object} domain.TokenResponse
 // @Failure 400 {object} domain.ErrorResponse "Invalid input"
 // @Failure 401 {object} domain.ErrorResponse "Unauthorized"
 // @Failure 500 {object} domain.ErrorResponse "Failed to signin"
 // @Router /api/users/signin [post]
 func (h *UserHandler) SignIn(c *fiber.Ctx) error {
-	id := new(string)
-	if err := c.BodyParser(id); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(domain.ErrorResponse{Error: "Invalid input"})
+	req := new(domain.SignInRequest)
+	if err := parseBody(c, req); err != nil {
+		return invalidInput(err)
 	}
 
-	tokenResponse, err := h.Usecase.SignIn(*id)
+	tokenResponse, err := h.Usecase.SignIn(req.IDToken)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(domain.ErrorResponse{Error: "Failed to signin"})
+		return fail(err, "Failed to signin")
 	}
 
 	return c.Status(fiber.StatusOK).JSON(tokenResponse)
@@ -351,18 +452,17 @@ func (h *UserHandler) SignIn(c *fiber.Ctx) error {
 // @security BearerAuth
 // @Description Add Staff By phone number
 // @Produce  json
-// @Param phone path string true "User Phone"
+// @Param phone path string true "User Phone, e.g. 0812345678 or +66812345678"
 // @Success 204
-// @Failure 400 {object} domain.ErrorResponse "User is already a staff"
+// @Failure 400 {object} domain.ErrorResponse "Invalid phone number"
+// @Failure 404 {object} domain.ErrorResponse "User not found"
+// @Failure 409 {object} domain.ErrorResponse "User is already a staff"
 // @Failure 500 {object} domain.ErrorResponse "Failed to add staff"
 // @Router /api/users/addstaff/{phone} [patch]
 func (h *UserHandler) AddStaff(c *fiber.Ctx) error {
 	phone := c.Params("phone")
 	if err := h.Usecase.AddStaff(phone); err != nil {
-		if errors.Is(err, domain.ErrUserAlreadyStaff) {
-			return c.Status(fiber.StatusBadRequest).JSON(domain.ErrorResponse{Error: "User is already a staff"})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(domain.ErrorResponse{Error: "Failed to add staff"})
+		return fail(err, "Failed to add staff")
 	}
 
 	return c.SendStatus(fiber.StatusNoContent)
@@ -382,7 +482,7 @@ func (h *UserHandler) GetImageURL(c *fiber.Ctx) error {
 	id := c.Params("id")
 	imageURL, err := h.Usecase.GetImageByUserId(id)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(domain.ErrorResponse{Error: "User not found"})
+		return fail(err, "Failed to fetch user")
 	}
 	return c.Status(fiber.StatusOK).JSON(domain.ImageResponse{URL: imageURL})
 }
@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/isd-sgcu/cutu2025-backend/domain"
+	"github.com/isd-sgcu/cutu2025-backend/utils"
+)
+
+// normalizer is implemented by requests that tidy their fields, e.g. trimming
+// or lower-casing them, before they are validated
+type normalizer interface {
+	Normalize()
+}
+
+// parseBody parses the request body into req and checks it against its
+// validate struct tags. Report errors with invalidInput.
+func parseBody(c *fiber.Ctx, req any) error {
+	if err := c.BodyParser(req); err != nil {
+		return err
+	}
+	if n, ok := req.(normalizer); ok {
+		n.Normalize()
+	}
+	return utils.Validate(req)
+}
+
+// parseQuery parses the query string into req and checks it like parseBody
+func parseQuery(c *fiber.Ctx, req any) error {
+	if err := c.QueryParser(req); err != nil {
+		return err
+	}
+	if n, ok := req.(normalizer); ok {
+		n.Normalize()
+	}
+	return utils.Validate(req)
+}
+
+// invalidInput reports a 400, listing every invalid field if the error is a validation error
+func invalidInput(err error) error {
+	var verr *domain.ValidationError
+	if errors.As(err, &verr) {
+		return verr
+	}
+	return fiber.NewError(fiber.StatusBadRequest, "Invalid input")
+}
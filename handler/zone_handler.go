@@ -0,0 +1,219 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/isd-sgcu/cutu2025-backend/domain"
+	"github.com/isd-sgcu/cutu2025-backend/usecase"
+)
+
+// ZoneHandler represents the handler for venue zones, their gates and occupancy
+type ZoneHandler struct {
+	Usecase *usecase.ZoneUsecase
+}
+
+// NewZoneHandler creates a new ZoneHandler
+func NewZoneHandler(usecase *usecase.ZoneUsecase) *ZoneHandler {
+	return &ZoneHandler{Usecase: usecase}
+}
+
+// Create godoc
+// @Summary Create a zone
+// @Description Create an area of the venue. A capacity of 0 means unlimited. Entry can be restricted to the listed statuses, roles and ticket types; an empty list allows everyone.
+// @Accept  json
+// @Produce  json
+// @security BearerAuth
+// @Param zone body domain.CreateZoneRequest true "Zone data"
+// @Success 201 {object} domain.Zone
+// @Failure 400 {object} domain.ErrorResponse "Invalid zone"
+// @Failure 401 {object} domain.ErrorResponse "Unauthorized"
+// @Failure 403 {object} domain.ErrorResponse "Forbidden"
+// @Failure 500 {object} domain.ErrorResponse "Failed to create zone"
+// @Router /api/zones [post]
+func (h *ZoneHandler) Create(c *fiber.Ctx) error {
+	req := new(domain.CreateZoneRequest)
+	if err := parseBody(c, req); err != nil {
+		return invalidInput(err)
+	}
+	zone := req.Zone()
+	if err := h.Usecase.Create(zone); err != nil {
+		return fail(err, "Failed to create zone")
+	}
+	return c.Status(fiber.StatusCreated).JSON(zone)
+}
+
+// GetAll godoc
+// @Summary Get all zones
+// @Produce  json
+// @security BearerAuth
+// @Success 200 {array} domain.Zone
+// @Failure 500 {object} domain.ErrorResponse "Failed to fetch zones"
+// @Router /api/zones [get]
+func (h *ZoneHandler) GetAll(c *fiber.Ctx) error {
+	zones, err := h.Usecase.GetAll()
+	if err != nil {
+		return fail(err, "Failed to fetch zones")
+	}
+	return c.Status(fiber.StatusOK).JSON(zones)
+}
+
+// Update godoc
+// @Summary Update zone by ID
+// @Description Rename a zone or change its capacity (0 means unlimited) and allow lists. Fields left out are not changed; send [] to clear an allow list.
+// @Accept  json
+// @security BearerAuth
+// @Param id path string true "Zone ID"
+// @Param zone body domain.UpdateZoneRequest true "Fields to change"
+// @Success 204
+// @Failure 400 {object} domain.ErrorResponse "Invalid zone"
+// @Failure 404 {object} domain.ErrorResponse "Zone not found"
+// @Failure 500 {object} domain.ErrorResponse "Failed to update zone"
+// @Router /api/zones/{id} [patch]
+func (h *ZoneHandler) Update(c *fiber.Ctx) error {
+	req := new(domain.UpdateZoneRequest)
+	if err := parseBody(c, req); err != nil {
+		return invalidInput(err)
+	}
+	if err := h.Usecase.Update(c.Params("id"), *req); err != nil {
+		return fail(err, "Failed to update zone")
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// Delete godoc
+// @Summary Delete zone by ID
+// @Description Delete a zone. Zones that gates still admit into cannot be deleted.
+// @security BearerAuth
+// @Param id path string true "Zone ID"
+// @Success 204
+// @Failure 404 {object} domain.ErrorResponse "Zone not found"
+// @Failure 409 {object} domain.ErrorResponse "Zone still has gates"
+// @Failure 500 {object} domain.ErrorResponse "Failed to delete zone"
+// @Router /api/zones/{id} [delete]
+func (h *ZoneHandler) Delete(c *fiber.Ctx) error {
+	if err := h.Usecase.Delete(c.Params("id")); err != nil {
+		return fail(err, "Failed to delete zone")
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// CreateGate godoc
+// @Summary Register a gate
+// @Description Register a scanning point and the zone it admits into. The gate ID is what scanners send as the gate query parameter.
+// @Accept  json
+// @Produce  json
+// @security BearerAuth
+// @Param gate body domain.Gate true "Gate data"
+// @Success 201 {object} domain.Gate
+// @Failure 400 {object} domain.ErrorResponse "Invalid gate"
+// @Failure 404 {object} domain.ErrorResponse "Zone not found"
+// @Failure 500 {object} domain.ErrorResponse "Failed to create gate"
+// @Router /api/gates [post]
+func (h *ZoneHandler) CreateGate(c *fiber.Ctx) error {
+	gate := new(domain.Gate)
+	if err := parseBody(c, gate); err != nil {
+		return invalidInput(err)
+	}
+	if err := h.Usecase.CreateGate(gate); err != nil {
+		return fail(err, "Failed to create gate")
+	}
+	return c.Status(fiber.StatusCreated).JSON(gate)
+}
+
+// GetAllGates godoc
+// @Summary Get all gates
+// @Produce  json
+// @security BearerAuth
+// @Success 200 {array} domain.Gate
+// @Failure 500 {object} domain.ErrorResponse "Failed to fetch gates"
+// @Router /api/gates [get]
+func (h *ZoneHandler) GetAllGates(c *fiber.Ctx) error {
+	gates, err := h.Usecase.GetAllGates()
+	if err != nil {
+		return fail(err, "Failed to fetch gates")
+	}
+	return c.Status(fiber.StatusOK).JSON(gates)
+}
+
+// DeleteGate godoc
+// @Summary Delete gate by ID
+// @security BearerAuth
+// @Param id path string true "Gate ID"
+// @Success 204
+// @Failure 404 {object} domain.ErrorResponse "Gate not found"
+// @Failure 500 {object} domain.ErrorResponse "Failed to delete gate"
+// @Router /api/gates/{id} [delete]
+func (h *ZoneHandler) DeleteGate(c *fiber.Ctx) error {
+	if err := h.Usecase.DeleteGate(c.Params("id")); err != nil {
+		return fail(err, "Failed to delete gate")
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// AssignStaff godoc
+// @Summary Assign staff to a gate
+// @Description Place a staff member at a gate. Their scans are then made at that gate and checked against its zone. Replaces any earlier assignment.
+// @Produce  json
+// @security BearerAuth
+// @Param id path string true "Gate ID"
+// @Param staffId path string true "Staff user ID"
+// @Success 200 {object} domain.GateAssignment
+// @Failure 400 {object} domain.ErrorResponse "User is not staff"
+// @Failure 404 {object} domain.ErrorResponse "Gate or user not found"
+// @Failure 500 {object} domain.ErrorResponse "Failed to assign staff"
+// @Router /api/gates/{id}/staff/{staffId} [put]
+func (h *ZoneHandler) AssignStaff(c *fiber.Ctx) error {
+	assignment, err := h.Usecase.AssignStaff(c.Params("id"), c.Params("staffId"))
+	if err != nil {
+		return fail(err, "Failed to assign staff")
+	}
+	return c.Status(fiber.StatusOK).JSON(assignment)
+}
+
+// UnassignStaff godoc
+// @Summary Unassign staff from a gate
+// @security BearerAuth
+// @Param id path string true "Gate ID"
+// @Param staffId path string true "Staff user ID"
+// @Success 204
+// @Failure 404 {object} domain.ErrorResponse "Staff is not assigned to this gate"
+// @Failure 500 {object} domain.ErrorResponse "Failed to unassign staff"
+// @Router /api/gates/{id}/staff/{staffId} [delete]
+func (h *ZoneHandler) UnassignStaff(c *fiber.Ctx) error {
+	if err := h.Usecase.UnassignStaff(c.Params("id"), c.Params("staffId")); err != nil {
+		return fail(err, "Failed to unassign staff")
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// GetGateStaff godoc
+// @Summary Get staff assigned to a gate
+// @Produce  json
+// @security BearerAuth
+// @Param id path string true "Gate ID"
+// @Success 200 {array} domain.GateAssignment
+// @Failure 404 {object} domain.ErrorResponse "Gate not found"
+// @Failure 500 {object} domain.ErrorResponse "Failed to fetch staff"
+// @Router /api/gates/{id}/staff [get]
+func (h *ZoneHandler) GetGateStaff(c *fiber.Ctx) error {
+	assignments, err := h.Usecase.GetGateStaff(c.Params("id"))
+	if err != nil {
+		return fail(err, "Failed to fetch staff")
+	}
+	return c.Status(fiber.StatusOK).JSON(assignments)
+}
+
+// GetOccupancy godoc
+// @Summary Get live occupancy
+// @Description Number of attendees currently inside the venue, overall and per zone
+// @Produce  json
+// @security BearerAuth
+// @Success 200 {object} domain.Occupancy
+// @Failure 500 {object} domain.ErrorResponse "Failed to fetch occupancy"
+// @Router /api/occupancy [get]
+func (h *ZoneHandler) GetOccupancy(c *fiber.Ctx) error {
+	occupancy, err := h.Usecase.GetOccupancy()
+	if err != nil {
+		return fail(err, "Failed to fetch occupancy")
+	}
+	return c.Status(fiber.StatusOK).JSON(occupancy)
+}
@@ -0,0 +1,33 @@
+package infrastructure
+
+import (
+	"log"
+	"time"
+
+	"github.com/MicahParks/keyfunc/v2"
+	"github.com/isd-sgcu/cutu2025-backend/config"
+)
+
+// ConnectToLineJWKS fetches LINE's JSON Web Key Set used to verify LIFF ID tokens
+// and keeps it refreshed in the background
+func ConnectToLineJWKS(cfg *config.Config) *keyfunc.JWKS {
+	if cfg.LineChannelID == "" {
+		log.Fatal("LINE channel ID not found in configuration")
+	}
+
+	jwks, err := keyfunc.Get(cfg.LineJWKSURL, keyfunc.Options{
+		RefreshInterval:   time.Hour,
+		RefreshRateLimit:  5 * time.Minute,
+		RefreshTimeout:    10 * time.Second,
+		RefreshUnknownKID: true,
+		RefreshErrorHandler: func(err error) {
+			log.Printf("Failed to refresh LINE JWKS: %v", err)
+		},
+	})
+	if err != nil {
+		log.Fatalf("Failed to fetch LINE JWKS from %s: %v", cfg.LineJWKSURL, err)
+	}
+
+	log.Println("Successfully fetched the LINE JWKS")
+	return jwks
+}
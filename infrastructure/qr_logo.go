@@ -0,0 +1,32 @@
+package infrastructure
+
+import (
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"log"
+	"os"
+
+	"github.com/isd-sgcu/cutu2025-backend/config"
+)
+
+// LoadQRLogo loads the optional logo drawn in the centre of rendered QR codes
+func LoadQRLogo(cfg *config.Config) image.Image {
+	if cfg.QRLogoPath == "" {
+		return nil
+	}
+
+	file, err := os.Open(cfg.QRLogoPath)
+	if err != nil {
+		log.Fatalf("Failed to open QR logo: %v", err)
+	}
+	defer file.Close()
+
+	logo, _, err := image.Decode(file)
+	if err != nil {
+		log.Fatalf("Failed to decode QR logo: %v", err)
+	}
+
+	log.Println("Successfully loaded the QR logo")
+	return logo
+}
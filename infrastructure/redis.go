@@ -0,0 +1,28 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/isd-sgcu/cutu2025-backend/config"
+	"github.com/redis/go-redis/v9"
+)
+
+// ConnectToRedis connects to Redis and checks it is reachable
+func ConnectToRedis(cfg *config.Config) *redis.Client {
+	client := redis.NewClient(&redis.Options{
+		Addr:     fmt.Sprintf("%s:%s", cfg.RedisHost, cfg.RedisPort),
+		Password: cfg.RedisPassword,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		log.Fatalf("Failed to connect to Redis: %v", err)
+	}
+
+	log.Println("Successfully connected to Redis")
+	return client
+}
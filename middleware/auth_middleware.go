@@ -1,39 +1,75 @@
 package middleware
 
 import (
+	"errors"
 	"strings"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/isd-sgcu/cutu2025-backend/domain"
 	"github.com/isd-sgcu/cutu2025-backend/usecase"
 	"github.com/isd-sgcu/cutu2025-backend/utils"
 )
 
-// AuthMiddleware verifies the JWT from the Authorization header
+const principalKey = "principal"
+
+// AuthMiddleware verifies the JWT from the Authorization header and stores the
+// caller's domain.Principal in the request context
 func AuthMiddleware(u *usecase.UserUsecase) fiber.Handler {
 	var secretKey = utils.GetEnv("SECRET_JWT_KEY", "")
 	return func(c *fiber.Ctx) error {
-		authHeader := c.Get("Authorization")
-		if !strings.HasPrefix(authHeader, "Bearer ") {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error": "Unauthorized",
-			})
+		if _, err := authenticate(c, u, secretKey); err != nil {
+			return err
 		}
 
-		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
+		return c.Next() // Continue if the token is valid
+	}
+}
 
-		id, err := utils.DecodeToken(tokenString, secretKey)
-		if err != nil {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error": "Invalid or expired token",
-			})
-		}
+// GetPrincipal returns the principal stored by AuthMiddleware
+func GetPrincipal(c *fiber.Ctx) (domain.Principal, bool) {
+	principal, ok := c.Locals(principalKey).(domain.Principal)
+	return principal, ok
+}
 
-		_, err = u.GetById(id)
+// authenticate resolves the caller's principal, reusing one already stored
+// earlier in the chain. Callers who cannot be authenticated get a 401 error;
+// other errors, e.g. from the database, are returned as they are.
+func authenticate(c *fiber.Ctx, u *usecase.UserUsecase, secretKey string) (domain.Principal, error) {
+	if principal, ok := GetPrincipal(c); ok {
+		return principal, nil
+	}
 
-		if err != nil {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "User not found"})
-		}
+	authHeader := c.Get("Authorization")
+	if !strings.HasPrefix(authHeader, "Bearer ") {
+		return domain.Principal{}, fiber.NewError(fiber.StatusUnauthorized, "Unauthorized")
+	}
 
-		return c.Next() // Continue if the token is valid
+	tokenString := strings.TrimPrefix(authHeader, "Bearer ")
+
+	claims, err := utils.DecodeToken(tokenString, secretKey)
+	if err != nil {
+		return domain.Principal{}, fiber.NewError(fiber.StatusUnauthorized, "Invalid or expired token")
+	}
+
+	return storePrincipal(c, u, claims)
+}
+
+// storePrincipal resolves the principal a verified token identifies and stores it in the request context
+func storePrincipal(c *fiber.Ctx, u *usecase.UserUsecase, claims *utils.AccessTokenClaims) (domain.Principal, error) {
+	role, err := u.GetRole(claims.UserID)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return domain.Principal{}, fiber.NewError(fiber.StatusUnauthorized, "User not found")
 	}
+	if err != nil {
+		return domain.Principal{}, err
+	}
+
+	principal := domain.Principal{
+		UserID:  claims.UserID,
+		Role:    role,
+		TokenID: claims.ID,
+	}
+	c.Locals(principalKey, principal)
+
+	return principal, nil
 }
@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/isd-sgcu/cutu2025-backend/domain"
+)
+
+// kindStatus is the HTTP status each kind of domain error is reported with.
+// Results about what was scanned are kept apart from 401 and 403, so scanners
+// can tell them from their own session expiring or lacking a role.
+var kindStatus = map[domain.ErrorKind]int{
+	domain.KindInvalid:       fiber.StatusBadRequest,
+	domain.KindUnauthorized:  fiber.StatusUnauthorized,
+	domain.KindForbidden:     fiber.StatusForbidden,
+	domain.KindNotFound:      fiber.StatusNotFound,
+	domain.KindConflict:      fiber.StatusConflict,
+	domain.KindUnprocessable: fiber.StatusUnprocessableEntity,
+}
+
+// ErrorHandler renders errors returned by handlers and middleware, so every
+// error response has the same shape and a machine-readable code
+func ErrorHandler(c *fiber.Ctx, err error) error {
+	status, response := ErrorResponse(err)
+	return c.Status(status).JSON(response)
+}
+
+// ErrorResponse returns the status and body err is reported with. Domain
+// errors carry their own code; errors the client cannot act on are logged and
+// reported as a 500 without their details.
+func ErrorResponse(err error) (int, domain.ErrorResponse) {
+	var verr *domain.ValidationError
+	if errors.As(err, &verr) {
+		return fiber.StatusBadRequest, domain.ErrorResponse{Error: "Invalid input", Code: "invalid_input", Fields: verr.Fields}
+	}
+
+	var derr *domain.Error
+	if errors.As(err, &derr) {
+		status, ok := kindStatus[derr.Kind]
+		if !ok {
+			status = fiber.StatusInternalServerError
+		}
+		return status, domain.ErrorResponse{Error: capitalize(derr.Message), Code: derr.Code}
+	}
+
+	var ferr *fiber.Error
+	if errors.As(err, &ferr) {
+		return ferr.Code, domain.ErrorResponse{Error: ferr.Message, Code: statusCode(ferr.Code)}
+	}
+
+	log.Printf("Unhandled error: %v", err)
+	return fiber.StatusInternalServerError, domain.ErrorResponse{Error: "Internal server error", Code: statusCode(fiber.StatusInternalServerError)}
+}
+
+// statusCode names an HTTP status as an error code, e.g. 404 as not_found
+func statusCode(status int) string {
+	if status == fiber.StatusInternalServerError {
+		return "internal_error"
+	}
+	return strings.ReplaceAll(strings.ToLower(http.StatusText(status)), " ", "_")
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(r)) + s[size:]
+}
@@ -16,11 +16,19 @@ func RequestLoggerMiddleware() fiber.Handler {
 		err := c.Next()               // Continue to the next middleware or handler
 		duration := time.Since(start) // Measure the duration
 
+		// Render any error now so the status it is reported with is logged. It
+		// is not returned, which would render and log it a second time.
+		if err != nil {
+			if herr := c.App().ErrorHandler(c, err); herr != nil {
+				log.Printf("Failed to render error %v: %v", err, herr)
+			}
+		}
+
 		status := c.Response().StatusCode() // Get the HTTP status code of the response
 
 		// Log the request information
 		log.Printf("[%s] %s %s took %v, Status: %d", time.Now().Format(time.RFC3339), method, path, duration, status)
 
-		return err
+		return nil
 	}
 }
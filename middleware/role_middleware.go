@@ -1,40 +1,26 @@
 package middleware
 
 import (
-	"strings"
-
 	"github.com/gofiber/fiber/v2"
 	"github.com/isd-sgcu/cutu2025-backend/domain"
 	"github.com/isd-sgcu/cutu2025-backend/usecase"
 	"github.com/isd-sgcu/cutu2025-backend/utils"
 )
 
+// RoleMiddleware authenticates the request like AuthMiddleware and only lets
+// callers holding one of allowedRoles through
 func RoleMiddleware(u *usecase.UserUsecase, allowedRoles ...domain.Role) fiber.Handler {
 	var secretKey = utils.GetEnv("SECRET_JWT_KEY", "")
 	return func(c *fiber.Ctx) error {
-		authHeader := c.Get("Authorization")
-		if !strings.HasPrefix(authHeader, "Bearer ") {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
-		}
-
-		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
-		id, err := utils.DecodeToken(tokenString, secretKey)
-		if err != nil {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Invalid or expired token"})
-		}
-
-		user, err := u.GetById(id)
+		principal, err := authenticate(c, u, secretKey)
 		if err != nil {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "User not found"})
+			return err
 		}
-		role := user.Role
 
-		for _, allowedRole := range allowedRoles {
-			if role == allowedRole {
-				return c.Next() // Role is allowed, proceed to the next handler
-			}
+		if principal.HasRole(allowedRoles...) {
+			return c.Next() // Role is allowed, proceed to the next handler
 		}
 
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Access forbidden: insufficient role permissions"})
+		return fiber.NewError(fiber.StatusForbidden, "Access forbidden: insufficient role permissions")
 	}
 }
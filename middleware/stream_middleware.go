@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/isd-sgcu/cutu2025-backend/domain"
+	"github.com/isd-sgcu/cutu2025-backend/usecase"
+	"github.com/isd-sgcu/cutu2025-backend/utils"
+)
+
+// StreamRoleMiddleware is RoleMiddleware for event streams. Browsers' EventSource
+// cannot send an Authorization header, so a stream token may be passed in the
+// "token" query parameter instead.
+func StreamRoleMiddleware(u *usecase.UserUsecase, allowedRoles ...domain.Role) fiber.Handler {
+	var secretKey = utils.GetEnv("SECRET_JWT_KEY", "")
+	return func(c *fiber.Ctx) error {
+		principal, err := authenticateStream(c, u, secretKey)
+		if err != nil {
+			return err
+		}
+
+		if principal.HasRole(allowedRoles...) {
+			return c.Next()
+		}
+
+		return fiber.NewError(fiber.StatusForbidden, "Access forbidden: insufficient role permissions")
+	}
+}
+
+// authenticateStream resolves the caller from a stream token in the query
+// string, falling back to the Authorization header
+func authenticateStream(c *fiber.Ctx, u *usecase.UserUsecase, secretKey string) (domain.Principal, error) {
+	tokenString := c.Query("token")
+	if tokenString == "" {
+		return authenticate(c, u, secretKey)
+	}
+
+	claims, err := utils.DecodeStreamToken(tokenString, secretKey)
+	if err != nil {
+		return domain.Principal{}, fiber.NewError(fiber.StatusUnauthorized, "Invalid or expired stream token")
+	}
+
+	return storePrincipal(c, u, claims)
+}
@@ -0,0 +1,332 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/isd-sgcu/cutu2025-backend/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CheckInRepository struct {
+	DB *gorm.DB
+}
+
+func NewCheckInRepository(db *gorm.DB) *CheckInRepository {
+	return &CheckInRepository{DB: db}
+}
+
+func (r *CheckInRepository) Create(checkIn *domain.CheckIn) error {
+	return r.DB.Create(checkIn).Error
+}
+
+// List returns check-ins matching the filter, newest first
+func (r *CheckInRepository) List(filter domain.CheckInFilter) ([]domain.CheckIn, error) {
+	query := r.DB.Model(&domain.CheckIn{})
+	if filter.UserID != "" {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	if filter.SessionID != "" {
+		query = query.Where("session_id = ?", filter.SessionID)
+	}
+	if filter.ScannerID != "" {
+		query = query.Where("scanner_id = ?", filter.ScannerID)
+	}
+	if filter.Gate != "" {
+		query = query.Where("gate = ?", filter.Gate)
+	}
+	if filter.ZoneID != "" {
+		query = query.Where("zone_id = ?", filter.ZoneID)
+	}
+	if filter.Direction != "" {
+		query = query.Where("direction = ?", filter.Direction)
+	}
+	if filter.Source != "" {
+		query = query.Where("source = ?", filter.Source)
+	}
+	if filter.Result != "" {
+		query = query.Where("result = ?", filter.Result)
+	}
+	if filter.From != nil {
+		query = query.Where("scanned_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("scanned_at < ?", *filter.To)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	if filter.Offset > 0 {
+		query = query.Offset(filter.Offset)
+	}
+
+	var checkIns []domain.CheckIn
+	err := query.Order("scanned_at DESC").Find(&checkIns).Error
+	return checkIns, err
+}
+
+func (r *CheckInRepository) GetById(id string) (domain.CheckIn, error) {
+	var checkIn domain.CheckIn
+	err := r.DB.Where("id = ?", id).First(&checkIn).Error
+	return checkIn, translateError(err, domain.ErrCheckInNotFound)
+}
+
+// GetEntryStats aggregates accepted entries made at or after from
+func (r *CheckInRepository) GetEntryStats(from time.Time) (domain.DashboardStats, error) {
+	stats := domain.DashboardStats{
+		From:      from,
+		PerMinute: []domain.MinuteCount{},
+		PerGate:   map[string]int64{},
+		PerStatus: map[domain.Status]int64{},
+	}
+	entries := r.DB.Model(&domain.CheckIn{}).
+		Where("check_ins.result = ? AND check_ins.direction = ? AND check_ins.scanned_at >= ?", domain.CheckInAccepted, domain.DirectionEntry, from)
+
+	if err := entries.Session(&gorm.Session{}).Count(&stats.Total).Error; err != nil {
+		return stats, err
+	}
+
+	if err := entries.Session(&gorm.Session{}).
+		Select("date_trunc('minute', check_ins.scanned_at) AS minute, COUNT(*) AS count").
+		Group("minute").Order("minute").
+		Scan(&stats.PerMinute).Error; err != nil {
+		return stats, err
+	}
+
+	var gates []struct {
+		Gate  string
+		Count int64
+	}
+	if err := entries.Session(&gorm.Session{}).
+		Select("check_ins.gate, COUNT(*) AS count").
+		Group("check_ins.gate").
+		Scan(&gates).Error; err != nil {
+		return stats, err
+	}
+	for _, g := range gates {
+		stats.PerGate[g.Gate] = g.Count
+	}
+
+	var statuses []struct {
+		Status domain.Status
+		Count  int64
+	}
+	if err := entries.Session(&gorm.Session{}).
+		Joins("JOIN users ON users.id = check_ins.user_id").
+		Select("users.status, COUNT(*) AS count").
+		Group("users.status").
+		Scan(&statuses).Error; err != nil {
+		return stats, err
+	}
+	for _, s := range statuses {
+		stats.PerStatus[s.Status] = s.Count
+	}
+
+	return stats, nil
+}
+
+// Admit atomically decides and records a scan of checkIn.UserID. The user's row
+// is locked while admit runs, so concurrent scans of the same user at different
+// gates are serialized and only one of them can be accepted. Entries into a zone
+// additionally lock the zone so its capacity cannot be overshot. If the scan is
+// admitted, checkIn is stored as accepted and the user's presence (and for
+// entries, LastEntered) is updated; otherwise nothing is written. The nonce of
+// a scanned QR code, if given, is consumed in the same transaction, so it is
+// only used up by an accepted scan; a nonce that is already used fails with
+// domain.ErrQRTokenReplayed.
+func (r *CheckInRepository) Admit(checkIn *domain.CheckIn, nonce *domain.QrNonce, admit func(user domain.User, last *domain.CheckIn, presence *domain.Presence) error) (domain.User, error) {
+	var user domain.User
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", checkIn.UserID).First(&user).Error
+		if err != nil {
+			return translateError(err, domain.ErrUserNotFound)
+		}
+
+		// Rolled back with the rest of the transaction if the scan is not admitted
+		if nonce != nil {
+			result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(nonce)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return domain.ErrQRTokenReplayed
+			}
+		}
+
+		var last *domain.CheckIn
+		if checkIn.SessionID != "" {
+			if last, err = getLastAccepted(tx, checkIn.UserID, checkIn.SessionID); err != nil {
+				return err
+			}
+		}
+		presence, err := getPresence(tx, checkIn.UserID)
+		if err != nil {
+			return err
+		}
+		if err := admit(user, last, presence); err != nil {
+			return err
+		}
+
+		if checkIn.Direction == domain.DirectionEntry && checkIn.ZoneID != "" {
+			if err := checkZoneCapacity(tx, checkIn.ZoneID, checkIn.UserID); err != nil {
+				return err
+			}
+		}
+
+		checkIn.Result = domain.CheckInAccepted
+		if err := tx.Create(checkIn).Error; err != nil {
+			return err
+		}
+
+		// The code is used up, so the attendee's next code must differ from it
+		if nonce != nil {
+			user.QrSeq++
+			if err := tx.Model(&domain.User{}).Where("id = ?", user.ID).UpdateColumn("qr_seq", user.QrSeq).Error; err != nil {
+				return err
+			}
+		}
+
+		// Scans uploaded late by offline devices must not override a more recent presence
+		if presence == nil || !checkIn.ScannedAt.Before(presence.UpdatedAt) {
+			if err := tx.Save(&domain.Presence{
+				UserID:    checkIn.UserID,
+				ZoneID:    checkIn.ZoneID,
+				Inside:    checkIn.Direction == domain.DirectionEntry,
+				UpdatedAt: checkIn.ScannedAt,
+			}).Error; err != nil {
+				return err
+			}
+		}
+
+		if checkIn.Direction == domain.DirectionEntry && (user.LastEntered == nil || user.LastEntered.Before(checkIn.ScannedAt)) {
+			user.LastEntered = &checkIn.ScannedAt
+			return tx.Model(&domain.User{}).Where("id = ?", user.ID).Update("last_entered", checkIn.ScannedAt).Error
+		}
+		return nil
+	})
+	return user, err
+}
+
+// Void marks an accepted check-in as voided and rolls the user's LastEntered and
+// presence back to what their remaining accepted check-ins say, so they can be
+// scanned again. The user's row is locked as in Admit.
+func (r *CheckInRepository) Void(id, voidedBy, reason string, at time.Time) (domain.CheckIn, error) {
+	var checkIn domain.CheckIn
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("id = ?", id).First(&checkIn).Error
+		if err != nil {
+			return translateError(err, domain.ErrCheckInNotFound)
+		}
+
+		// Lock the user before the check-in, in the same order as Admit
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", checkIn.UserID).
+			Find(&domain.User{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&checkIn).Error; err != nil {
+			return err
+		}
+		switch checkIn.Result {
+		case domain.CheckInAccepted:
+		case domain.CheckInVoided:
+			return domain.ErrCheckInAlreadyVoided
+		default:
+			return domain.ErrCheckInNotVoidable
+		}
+
+		checkIn.Result = domain.CheckInVoided
+		checkIn.VoidedBy = voidedBy
+		checkIn.VoidedAt = &at
+		checkIn.VoidReason = reason
+		if err := tx.Save(&checkIn).Error; err != nil {
+			return err
+		}
+
+		return restoreUserState(tx, checkIn.UserID)
+	})
+	return checkIn, err
+}
+
+// restoreUserState recomputes the user's LastEntered and presence from their accepted check-ins
+func restoreUserState(tx *gorm.DB, userID string) error {
+	accepted := tx.Where("user_id = ? AND result = ?", userID, domain.CheckInAccepted).Order("scanned_at DESC")
+
+	var lastEntered *time.Time
+	var lastEntry domain.CheckIn
+	err := accepted.Session(&gorm.Session{}).Where("direction = ?", domain.DirectionEntry).First(&lastEntry).Error
+	if err == nil {
+		lastEntered = &lastEntry.ScannedAt
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	if err := tx.Model(&domain.User{}).Where("id = ?", userID).Update("last_entered", lastEntered).Error; err != nil {
+		return err
+	}
+
+	var last domain.CheckIn
+	err = accepted.Session(&gorm.Session{}).First(&last).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return tx.Where("user_id = ?", userID).Delete(&domain.Presence{}).Error
+	}
+	if err != nil {
+		return err
+	}
+	return tx.Save(&domain.Presence{
+		UserID:    userID,
+		ZoneID:    last.ZoneID,
+		Inside:    last.Direction == domain.DirectionEntry,
+		UpdatedAt: last.ScannedAt,
+	}).Error
+}
+
+// checkZoneCapacity locks the zone and fails with domain.ErrZoneFull if
+// admitting userID would exceed its capacity
+func checkZoneCapacity(tx *gorm.DB, zoneID, userID string) error {
+	var zone domain.Zone
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", zoneID).First(&zone).Error
+	if err != nil {
+		return translateError(err, domain.ErrZoneNotFound)
+	}
+	if zone.Capacity <= 0 {
+		return nil
+	}
+
+	var occupancy int64
+	if err := tx.Model(&domain.Presence{}).
+		Where("zone_id = ? AND inside AND user_id <> ?", zoneID, userID).
+		Count(&occupancy).Error; err != nil {
+		return err
+	}
+	if occupancy >= int64(zone.Capacity) {
+		return domain.ErrZoneFull
+	}
+	return nil
+}
+
+// getPresence returns the user's presence, or nil if they have never been scanned in
+func getPresence(db *gorm.DB, userID string) (*domain.Presence, error) {
+	var presence domain.Presence
+	err := db.Where("user_id = ?", userID).First(&presence).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &presence, nil
+}
+
+// getLastAccepted returns the user's most recent accepted scan in the session, or nil if there is none
+func getLastAccepted(db *gorm.DB, userID, sessionID string) (*domain.CheckIn, error) {
+	var checkIn domain.CheckIn
+	err := db.Where("user_id = ? AND session_id = ? AND result = ?", userID, sessionID, domain.CheckInAccepted).
+		Order("scanned_at DESC").First(&checkIn).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &checkIn, nil
+}
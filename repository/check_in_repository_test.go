@@ -0,0 +1,198 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/isd-sgcu/cutu2025-backend/domain"
+	"github.com/isd-sgcu/cutu2025-backend/repository"
+	"github.com/testcontainers/testcontainers-go"
+	tcpostgres "github.com/testcontainers/testcontainers-go/modules/postgres"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// openTestDB connects to the database in TEST_DATABASE_URL, or starts a
+// throwaway Postgres container if it is unset. The test is skipped when
+// neither is available.
+func openTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		skipWithoutDocker(t)
+
+		ctx := context.Background()
+		container, err := tcpostgres.Run(ctx, "postgres:16-alpine",
+			tcpostgres.WithDatabase("cutu"),
+			tcpostgres.WithUsername("cutu"),
+			tcpostgres.WithPassword("cutu"),
+			tcpostgres.BasicWaitStrategies(),
+		)
+		if err != nil {
+			t.Fatalf("start postgres: %v", err)
+		}
+		t.Cleanup(func() { container.Terminate(context.Background()) })
+
+		if dsn, err = container.ConnectionString(ctx, "sslmode=disable"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("connect to postgres: %v", err)
+	}
+	if err := db.AutoMigrate(&domain.User{}, &domain.CheckIn{}, &domain.Zone{}, &domain.Presence{}, &domain.QrNonce{}, &domain.RegistrationQuota{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB.SetMaxOpenConns(20)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	return db
+}
+
+// skipWithoutDocker skips the test if no container runtime is reachable.
+// testcontainers panics rather than skipping when there is no Docker host.
+func skipWithoutDocker(t *testing.T) {
+	t.Helper()
+	defer func() {
+		if r := recover(); r != nil {
+			t.Skipf("Docker is not available: %v", r)
+		}
+	}()
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+}
+
+func createTestUser(t *testing.T, db *gorm.DB) domain.User {
+	t.Helper()
+	user := domain.User{
+		ID:           uuid.NewString(),
+		UID:          uuid.NewString(),
+		Name:         "Test Attendee",
+		Phone:        uuid.NewString()[:10],
+		Status:       domain.StatusGeneralPublic,
+		RegisteredAt: time.Now(),
+		Role:         domain.Member,
+	}
+	if err := db.Create(&user).Error; err != nil {
+		t.Fatal(err)
+	}
+	return user
+}
+
+func newEntry(userID, sessionID string) *domain.CheckIn {
+	return &domain.CheckIn{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		SessionID: sessionID,
+		Direction: domain.DirectionEntry,
+		Source:    domain.SourceOnline,
+		ScannedAt: time.Now(),
+	}
+}
+
+func TestCheckInRepositoryAdmitConcurrentScans(t *testing.T) {
+	db := openTestDB(t)
+	repo := repository.NewCheckInRepository(db)
+	user := createTestUser(t, db)
+	sessionID := uuid.NewString()
+
+	// Every gate scans the same code at once; only the first scan may let them in
+	const scans = 50
+	var (
+		wg       sync.WaitGroup
+		start    = make(chan struct{})
+		mu       sync.Mutex
+		accepted int
+		rejected int
+		failures []error
+	)
+	for i := 0; i < scans; i++ {
+		wg.Add(1)
+		go func(gate int) {
+			defer wg.Done()
+			<-start
+
+			checkIn := newEntry(user.ID, sessionID)
+			checkIn.Gate = fmt.Sprintf("gate-%d", gate)
+			_, err := repo.Admit(checkIn, nil, func(_ domain.User, last *domain.CheckIn, _ *domain.Presence) error {
+				if last != nil {
+					return domain.ErrUserAlreadyEntered
+				}
+				return nil
+			})
+
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				accepted++
+			case errors.Is(err, domain.ErrUserAlreadyEntered):
+				rejected++
+			default:
+				failures = append(failures, err)
+			}
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	for _, err := range failures {
+		t.Errorf("Admit() unexpected error: %v", err)
+	}
+	if accepted != 1 || rejected != scans-1 {
+		t.Fatalf("accepted %d and rejected %d scans, want 1 and %d", accepted, rejected, scans-1)
+	}
+
+	var stored int64
+	if err := db.Model(&domain.CheckIn{}).
+		Where("user_id = ? AND result = ?", user.ID, domain.CheckInAccepted).
+		Count(&stored).Error; err != nil {
+		t.Fatal(err)
+	}
+	if stored != 1 {
+		t.Fatalf("stored %d accepted check-ins, want 1", stored)
+	}
+}
+
+func TestCheckInRepositoryAdmitConsumesNonceOnlyWhenAccepted(t *testing.T) {
+	db := openTestDB(t)
+	repo := repository.NewCheckInRepository(db)
+	user := createTestUser(t, db)
+	sessionID := uuid.NewString()
+
+	nonce := func() *domain.QrNonce {
+		return &domain.QrNonce{Nonce: "nonce-" + user.ID, UserID: user.ID, ExpiresAt: time.Now().Add(time.Minute)}
+	}
+	refuse := func(domain.User, *domain.CheckIn, *domain.Presence) error { return domain.ErrZoneAccessDenied }
+	allow := func(domain.User, *domain.CheckIn, *domain.Presence) error { return nil }
+
+	// Turned away at the wrong gate: the code must still be usable
+	if _, err := repo.Admit(newEntry(user.ID, sessionID), nonce(), refuse); !errors.Is(err, domain.ErrZoneAccessDenied) {
+		t.Fatalf("Admit() error = %v, want %v", err, domain.ErrZoneAccessDenied)
+	}
+	admitted, err := repo.Admit(newEntry(user.ID, sessionID), nonce(), allow)
+	if err != nil {
+		t.Fatalf("Admit() after a refused scan error = %v, want nil", err)
+	}
+	// The attendee's next code must differ from the one just used
+	if admitted.QrSeq != user.QrSeq+1 {
+		t.Fatalf("QrSeq after an accepted scan = %d, want %d", admitted.QrSeq, user.QrSeq+1)
+	}
+	if _, err := repo.Admit(newEntry(user.ID, sessionID), nonce(), allow); !errors.Is(err, domain.ErrQRTokenReplayed) {
+		t.Fatalf("Admit() with a used nonce error = %v, want %v", err, domain.ErrQRTokenReplayed)
+	}
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/isd-sgcu/cutu2025-backend/domain"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// pgUniqueViolation is the Postgres error code for a unique constraint violation
+const pgUniqueViolation = "23505"
+
+// uniqueErrors maps the name of a unique constraint to the error reported when
+// it is violated. The names are the ones GORM gives the constraints it creates
+// for `unique` fields, uni_<table>_<column>.
+var uniqueErrors = map[string]error{
+	"uni_users_uid":   domain.ErrUIDTaken,
+	"uni_users_phone": domain.ErrPhoneTaken,
+}
+
+// translateError turns database errors into domain errors: a missing record
+// into notFound, or domain.ErrNotFound if notFound is nil, and a unique
+// violation into the error for its constraint, or domain.ErrConflict. Other
+// errors are returned unchanged.
+func translateError(err error, notFound error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		if notFound == nil {
+			return domain.ErrNotFound
+		}
+		return notFound
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+		if err, ok := uniqueErrors[pgErr.ConstraintName]; ok {
+			return err
+		}
+		return domain.ErrConflict
+	}
+	return err
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/isd-sgcu/cutu2025-backend/domain"
+	"gorm.io/gorm"
+)
+
+type EventRepository struct {
+	DB *gorm.DB
+}
+
+func NewEventRepository(db *gorm.DB) *EventRepository {
+	return &EventRepository{DB: db}
+}
+
+func (r *EventRepository) Create(event *domain.Event) error {
+	return translateError(r.DB.Create(event).Error, nil)
+}
+
+func (r *EventRepository) GetAll() ([]domain.Event, error) {
+	var events []domain.Event
+	err := r.DB.Preload("Sessions", func(db *gorm.DB) *gorm.DB {
+		return db.Order("starts_at")
+	}).Order("created_at").Find(&events).Error
+	return events, err
+}
+
+func (r *EventRepository) GetById(id string) (domain.Event, error) {
+	var event domain.Event
+	err := r.DB.Preload("Sessions", func(db *gorm.DB) *gorm.DB {
+		return db.Order("starts_at")
+	}).Where("id = ?", id).First(&event).Error
+	return event, translateError(err, domain.ErrEventNotFound)
+}
+
+func (r *EventRepository) Delete(id string) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("event_id = ?", id).Delete(&domain.Session{}).Error; err != nil {
+			return err
+		}
+		result := tx.Where("id = ?", id).Delete(&domain.Event{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrEventNotFound
+		}
+		return nil
+	})
+}
+
+func (r *EventRepository) CreateSession(session *domain.Session) error {
+	return translateError(r.DB.Create(session).Error, nil)
+}
+
+func (r *EventRepository) GetSessionById(id string) (domain.Session, error) {
+	var session domain.Session
+	err := r.DB.Where("id = ?", id).First(&session).Error
+	return session, translateError(err, domain.ErrSessionNotFound)
+}
+
+func (r *EventRepository) UpdateSession(session *domain.Session) error {
+	return r.DB.Save(session).Error
+}
+
+func (r *EventRepository) DeleteSession(id string) error {
+	result := r.DB.Where("id = ?", id).Delete(&domain.Session{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrSessionNotFound
+	}
+	return nil
+}
+
+// GetActiveSession returns the session open at t
+func (r *EventRepository) GetActiveSession(t time.Time) (domain.Session, error) {
+	var session domain.Session
+	err := r.DB.Where("starts_at <= ? AND ends_at > ?", t, t).Order("starts_at DESC").First(&session).Error
+	return session, translateError(err, domain.ErrNoActiveSession)
+}
+
+// HasSessions reports whether any session has been configured
+func (r *EventRepository) HasSessions() (bool, error) {
+	var count int64
+	err := r.DB.Model(&domain.Session{}).Limit(1).Count(&count).Error
+	return count > 0, err
+}
+
+// HasOverlappingSession reports whether any session other than excludeID overlaps [start, end)
+func (r *EventRepository) HasOverlappingSession(start, end time.Time, excludeID string) (bool, error) {
+	var count int64
+	err := r.DB.Model(&domain.Session{}).
+		Where("starts_at < ? AND ends_at > ? AND id <> ?", end, start, excludeID).
+		Count(&count).Error
+	return count > 0, err
+}
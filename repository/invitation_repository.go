@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/isd-sgcu/cutu2025-backend/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type InvitationRepository struct {
+	DB *gorm.DB
+}
+
+func NewInvitationRepository(db *gorm.DB) *InvitationRepository {
+	return &InvitationRepository{DB: db}
+}
+
+func (r *InvitationRepository) CreateBatch(invitations []domain.Invitation) error {
+	return translateError(r.DB.Create(&invitations).Error, nil)
+}
+
+func (r *InvitationRepository) GetAll(batchID string) ([]domain.Invitation, error) {
+	var invitations []domain.Invitation
+	query := r.DB.Order("created_at DESC, code")
+	if batchID != "" {
+		query = query.Where("batch_id = ?", batchID)
+	}
+	err := query.Find(&invitations).Error
+	return invitations, err
+}
+
+func (r *InvitationRepository) GetByCode(code string) (domain.Invitation, error) {
+	var invitation domain.Invitation
+	err := r.DB.Where("code = ?", code).First(&invitation).Error
+	return invitation, translateError(err, domain.ErrInvitationNotFound)
+}
+
+// Revoke stops a code from being redeemed again
+func (r *InvitationRepository) Revoke(code string, at time.Time) error {
+	result := r.DB.Model(&domain.Invitation{}).Where("code = ? AND revoked_at IS NULL", code).Update("revoked_at", at)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		if _, err := r.GetByCode(code); err != nil {
+			return err
+		}
+		return domain.ErrInvitationRevoked
+	}
+	return nil
+}
+
+// Redeem atomically registers user with an invitation code. The invitation is
+// locked while redeem checks it and applies what it grants to user, so
+// concurrent registrations cannot use a code more than MaxUses times.
+func (r *InvitationRepository) Redeem(code string, user *domain.User, at time.Time, redeem func(invitation domain.Invitation) error) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		var invitation domain.Invitation
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("code = ?", code).First(&invitation).Error
+		if err != nil {
+			return translateError(err, domain.ErrInvitationNotFound)
+		}
+		if err := redeem(invitation); err != nil {
+			return err
+		}
+
+		if err := createUser(tx, user); err != nil {
+			return err
+		}
+		if err := tx.Model(&domain.Invitation{}).Where("code = ?", code).Update("uses", gorm.Expr("uses + 1")).Error; err != nil {
+			return err
+		}
+		return tx.Create(&domain.InvitationRedemption{
+			ID:         uuid.NewString(),
+			Code:       code,
+			UserID:     user.ID,
+			RedeemedAt: at,
+		}).Error
+	})
+}
+
+// GetRedemptions lists who redeemed the code, or every code in the batch
+func (r *InvitationRepository) GetRedemptions(code, batchID string) ([]domain.RedemptionDetail, error) {
+	redemptions := []domain.RedemptionDetail{}
+	query := r.DB.Model(&domain.InvitationRedemption{}).
+		Select("invitation_redemptions.code, invitation_redemptions.user_id, users.uid, users.name, users.phone, invitation_redemptions.redeemed_at").
+		Joins("LEFT JOIN users ON users.id = invitation_redemptions.user_id").
+		Order("invitation_redemptions.redeemed_at DESC")
+	if code != "" {
+		query = query.Where("invitation_redemptions.code = ?", code)
+	}
+	if batchID != "" {
+		query = query.Joins("JOIN invitations ON invitations.code = invitation_redemptions.code").
+			Where("invitations.batch_id = ?", batchID)
+	}
+	err := query.Scan(&redemptions).Error
+	return redemptions, err
+}
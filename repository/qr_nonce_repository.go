@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/isd-sgcu/cutu2025-backend/domain"
+	"gorm.io/gorm"
+)
+
+type QrNonceRepository struct {
+	DB *gorm.DB
+}
+
+func NewQrNonceRepository(db *gorm.DB) *QrNonceRepository {
+	return &QrNonceRepository{DB: db}
+}
+
+// DeleteExpired removes nonces whose tokens can no longer be scanned anyway
+func (r *QrNonceRepository) DeleteExpired(before time.Time) error {
+	return r.DB.Where("expires_at < ?", before).Delete(&domain.QrNonce{}).Error
+}
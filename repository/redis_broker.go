@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"github.com/isd-sgcu/cutu2025-backend/domain"
+	"github.com/isd-sgcu/cutu2025-backend/utils"
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisCheckInBroker publishes check-in events over Redis pub/sub so that
+// dashboards connected to any instance see scans made through every instance
+type RedisCheckInBroker struct {
+	Client  *redis.Client
+	Channel string
+	local   *utils.Broker[domain.CheckInEvent]
+}
+
+// NewRedisCheckInBroker subscribes to the channel and relays its messages to
+// local subscribers until ctx is cancelled
+func NewRedisCheckInBroker(ctx context.Context, client *redis.Client, channel string) *RedisCheckInBroker {
+	b := &RedisCheckInBroker{
+		Client:  client,
+		Channel: channel,
+		local:   utils.NewBroker[domain.CheckInEvent](),
+	}
+
+	pubsub := client.Subscribe(ctx, channel)
+	go func() {
+		defer pubsub.Close()
+		for msg := range pubsub.Channel() {
+			var event domain.CheckInEvent
+			if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+				log.Printf("Failed to decode check-in event: %v", err)
+				continue
+			}
+			_ = b.local.Publish(event)
+		}
+	}()
+
+	return b
+}
+
+func (b *RedisCheckInBroker) Publish(event domain.CheckInEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return b.Client.Publish(context.Background(), b.Channel, payload).Err()
+}
+
+func (b *RedisCheckInBroker) Subscribe() (<-chan domain.CheckInEvent, func()) {
+	return b.local.Subscribe()
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/isd-sgcu/cutu2025-backend/domain"
+	"gorm.io/gorm"
+)
+
+type RefreshTokenRepository struct {
+	DB *gorm.DB
+}
+
+func NewRefreshTokenRepository(db *gorm.DB) *RefreshTokenRepository {
+	return &RefreshTokenRepository{DB: db}
+}
+
+func (r *RefreshTokenRepository) Create(token *domain.RefreshToken) error {
+	return r.DB.Create(token).Error
+}
+
+func (r *RefreshTokenRepository) GetByHash(hash string) (domain.RefreshToken, error) {
+	var token domain.RefreshToken
+	err := r.DB.Where("token_hash = ?", hash).First(&token).Error
+	return token, translateError(err, domain.ErrInvalidRefreshToken)
+}
+
+// MarkUsed flags the token as rotated. It reports false if the token was
+// already used or revoked, so two concurrent refreshes cannot both succeed.
+func (r *RefreshTokenRepository) MarkUsed(id string) (bool, error) {
+	result := r.DB.Model(&domain.RefreshToken{}).
+		Where("id = ? AND used_at IS NULL AND revoked_at IS NULL", id).
+		Update("used_at", time.Now())
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *RefreshTokenRepository) RevokeFamily(familyID string) error {
+	return r.DB.Model(&domain.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", time.Now()).Error
+}
+
+func (r *RefreshTokenRepository) RevokeByUserId(userID string) error {
+	return r.DB.Model(&domain.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error
+}
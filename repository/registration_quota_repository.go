@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/isd-sgcu/cutu2025-backend/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RegistrationQuotaRepository struct {
+	DB *gorm.DB
+}
+
+func NewRegistrationQuotaRepository(db *gorm.DB) *RegistrationQuotaRepository {
+	return &RegistrationQuotaRepository{DB: db}
+}
+
+// Set creates or changes a quota. Raising it promotes waitlisted users into
+// the new places; lowering it leaves confirmed users where they are.
+func (r *RegistrationQuotaRepository) Set(quota *domain.RegistrationQuota) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "status"}},
+			DoUpdates: clause.AssignmentColumns([]string{"capacity", "updated_at"}),
+		}).Create(quota).Error
+		if err != nil {
+			return err
+		}
+		return promoteWaitlist(tx, quota.Status)
+	})
+}
+
+// Delete removes a quota, which confirms everyone waiting for that status
+func (r *RegistrationQuotaRepository) Delete(status domain.Status) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("status = ?", status).Delete(&domain.RegistrationQuota{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrQuotaNotFound
+		}
+		return promoteWaitlist(tx, status)
+	})
+}
+
+// GetUsage reports every quota with how many users are confirmed and waiting,
+// along with statuses that have users but no quota
+func (r *RegistrationQuotaRepository) GetUsage() ([]domain.QuotaUsage, error) {
+	var quotas []domain.RegistrationQuota
+	if err := r.DB.Find(&quotas).Error; err != nil {
+		return nil, err
+	}
+
+	var counts []struct {
+		Status       domain.Status
+		Registration domain.Registration
+		Count        int64
+	}
+	err := r.DB.Model(&domain.User{}).
+		Select("status, registration, COUNT(*) AS count").
+		Group("status, registration").
+		Scan(&counts).Error
+	if err != nil {
+		return nil, err
+	}
+
+	usage := []domain.QuotaUsage{}
+	index := make(map[domain.Status]int)
+	entry := func(status domain.Status) *domain.QuotaUsage {
+		i, ok := index[status]
+		if !ok {
+			i = len(usage)
+			index[status] = i
+			usage = append(usage, domain.QuotaUsage{Status: status})
+		}
+		return &usage[i]
+	}
+	for _, quota := range quotas {
+		capacity := quota.Capacity
+		entry(quota.Status).Capacity = &capacity
+	}
+	for _, count := range counts {
+		if count.Registration == domain.RegistrationWaitlisted {
+			entry(count.Status).Waitlisted += count.Count
+		} else {
+			entry(count.Status).Confirmed += count.Count
+		}
+	}
+	return usage, nil
+}
+
+// GetWaitlistPosition reports where the user stands in their status's waitlist
+func (r *RegistrationQuotaRepository) GetWaitlistPosition(userID string) (domain.WaitlistPosition, error) {
+	var user domain.User
+	err := r.DB.Where("id = ?", userID).First(&user).Error
+	if err != nil {
+		return domain.WaitlistPosition{}, translateError(err, domain.ErrUserNotFound)
+	}
+
+	position := domain.WaitlistPosition{Registration: user.Registration, Status: user.Status}
+	waitlist := r.DB.Model(&domain.User{}).Where("status = ? AND registration = ?", user.Status, domain.RegistrationWaitlisted)
+	if err := waitlist.Session(&gorm.Session{}).Count(&position.Waitlisted).Error; err != nil {
+		return domain.WaitlistPosition{}, err
+	}
+	if user.Registration != domain.RegistrationWaitlisted {
+		return position, nil
+	}
+
+	// Users are promoted in the order they registered
+	err = waitlist.Session(&gorm.Session{}).
+		Where("(registered_at < ? OR (registered_at = ? AND id <= ?))", user.RegisteredAt, user.RegisteredAt, user.ID).
+		Count(&position.Position).Error
+	return position, err
+}
+
+// lockQuota locks the quota for status so places are handed out one at a
+// time, returning nil if the status is unlimited
+func lockQuota(tx *gorm.DB, status domain.Status) (*domain.RegistrationQuota, error) {
+	var quota domain.RegistrationQuota
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("status = ?", status).First(&quota).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &quota, nil
+}
+
+func countConfirmed(tx *gorm.DB, status domain.Status) (int64, error) {
+	var confirmed int64
+	err := tx.Model(&domain.User{}).
+		Where("status = ? AND registration = ?", status, domain.RegistrationConfirmed).
+		Count(&confirmed).Error
+	return confirmed, err
+}
+
+// placeUser confirms a new user if their status has room and waitlists them otherwise
+func placeUser(tx *gorm.DB, user *domain.User) error {
+	quota, err := lockQuota(tx, user.Status)
+	if err != nil {
+		return err
+	}
+	user.Registration = domain.RegistrationConfirmed
+	if quota == nil {
+		return nil
+	}
+
+	confirmed, err := countConfirmed(tx, user.Status)
+	if err != nil {
+		return err
+	}
+	if confirmed >= int64(quota.Capacity) {
+		user.Registration = domain.RegistrationWaitlisted
+	}
+	return nil
+}
+
+// promoteWaitlist confirms waitlisted users of status, earliest first, until
+// the quota is full
+func promoteWaitlist(tx *gorm.DB, status domain.Status) error {
+	quota, err := lockQuota(tx, status)
+	if err != nil {
+		return err
+	}
+
+	waitlist := tx.Model(&domain.User{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("status = ? AND registration = ?", status, domain.RegistrationWaitlisted).
+		Order("registered_at, id")
+	if quota != nil {
+		confirmed, err := countConfirmed(tx, status)
+		if err != nil {
+			return err
+		}
+		places := int64(quota.Capacity) - confirmed
+		if places <= 0 {
+			return nil
+		}
+		waitlist = waitlist.Limit(int(places))
+	}
+
+	var ids []string
+	if err := waitlist.Pluck("id", &ids).Error; err != nil || len(ids) == 0 {
+		return err
+	}
+	return tx.Model(&domain.User{}).Where("id IN ?", ids).Update("registration", domain.RegistrationConfirmed).Error
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"github.com/isd-sgcu/cutu2025-backend/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RoleGrantRepository struct {
+	DB *gorm.DB
+}
+
+func NewRoleGrantRepository(db *gorm.DB) *RoleGrantRepository {
+	return &RoleGrantRepository{DB: db}
+}
+
+// Upsert creates the grant, or changes the role of an existing grant for the
+// same LIFF ID. grant is filled in with the stored row.
+func (r *RoleGrantRepository) Upsert(grant *domain.RoleGrant) error {
+	return r.DB.Clauses(
+		clause.OnConflict{
+			Columns:   []clause.Column{{Name: "type"}, {Name: "value"}},
+			DoUpdates: clause.AssignmentColumns([]string{"role", "created_by", "created_at"}),
+		},
+		clause.Returning{},
+	).Create(grant).Error
+}
+
+func (r *RoleGrantRepository) GetAll() ([]domain.RoleGrant, error) {
+	var grants []domain.RoleGrant
+	err := r.DB.Order("created_at").Find(&grants).Error
+	return grants, err
+}
+
+func (r *RoleGrantRepository) Delete(id string) error {
+	result := r.DB.Where("id = ?", id).Delete(&domain.RoleGrant{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrRoleGrantNotFound
+	}
+	return nil
+}
+
+// GetForUser returns the grants matching a LIFF ID
+func (r *RoleGrantRepository) GetForUser(lineID string) ([]domain.RoleGrant, error) {
+	var grants []domain.RoleGrant
+	err := r.DB.Where("type = ? AND value = ?", domain.GrantByLineID, lineID).Find(&grants).Error
+	return grants, err
+}
+
+func (r *RoleGrantRepository) HasAdmin() (bool, error) {
+	var count int64
+	if err := r.DB.Model(&domain.RoleGrant{}).Where("role = ?", domain.Admin).Count(&count).Error; err != nil {
+		return false, err
+	}
+	if count > 0 {
+		return true, nil
+	}
+	err := r.DB.Model(&domain.User{}).Where("role = ?", domain.Admin).Count(&count).Error
+	return count > 0, err
+}
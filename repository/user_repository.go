@@ -1,8 +1,15 @@
 package repository
 
 import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
 	"github.com/isd-sgcu/cutu2025-backend/domain"
+	"github.com/isd-sgcu/cutu2025-backend/utils"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type UserRepository struct {
@@ -13,8 +20,24 @@ func NewUserRepository(db *gorm.DB) *UserRepository {
 	return &UserRepository{DB: db}
 }
 
+// Create inserts the user, failing with domain.ErrUIDTaken if the UID is already in use
 func (r *UserRepository) Create(user *domain.User) error {
-	return r.DB.Create(user).Error
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		return createUser(tx, user)
+	})
+}
+
+// createUser inserts the user, confirming or waitlisting them according to
+// their status's registration quota
+func createUser(tx *gorm.DB, user *domain.User) error {
+	if err := placeUser(tx, user); err != nil {
+		return err
+	}
+	if err := tx.Create(user).Error; err != nil {
+		return translateError(err, nil)
+	}
+	// A user who signs up again after being deleted is no longer removed
+	return tx.Where("user_id = ?", user.ID).Delete(&domain.UserTombstone{}).Error
 }
 
 func (r *UserRepository) GetAll() ([]domain.User, error) {
@@ -26,36 +49,228 @@ func (r *UserRepository) GetAll() ([]domain.User, error) {
 func (r *UserRepository) GetById(id string) (domain.User, error) {
 	var user domain.User
 	err := r.DB.Where("id = ?", id).First(&user).Error
-	return user, err
+	return user, translateError(err, domain.ErrUserNotFound)
 }
 
-func (r *UserRepository) GetByName(name string) ([]domain.User, error) {
-	var users []domain.User
-	err := r.DB.Where("name ILIKE ?", "%"+name+"%").Find(&users).Error
-	return users, err
+// userSortColumns are the columns users can be ordered by, keyed by the
+// field names clients sort by
+var userSortColumns = map[string]string{
+	"registeredAt": "registered_at",
+	"updatedAt":    "updated_at",
+	"lastEntered":  "last_entered",
+	"name":         "name",
+	"uid":          "uid",
+}
+
+// List returns a page of the users matching the query and how many match in all
+func (r *UserRepository) List(query domain.UserListQuery) ([]domain.User, int64, error) {
+	filtered := filterUsers(r.DB.Model(&domain.User{}), query.UserFilter)
+
+	var total int64
+	if err := filtered.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	direction := "ASC"
+	sort := query.Sort
+	if strings.HasPrefix(sort, "-") {
+		direction, sort = "DESC", sort[1:]
+	}
+	column, ok := userSortColumns[sort]
+	if !ok {
+		column, direction = "registered_at", "DESC"
+	}
+
+	users := []domain.User{}
+	err := filtered.Session(&gorm.Session{}).
+		Order(fmt.Sprintf("%s %s NULLS LAST", column, direction)).
+		Order("id").
+		Limit(query.Limit).
+		Offset(query.Offset).
+		Find(&users).Error
+	return users, total, err
+}
+
+// Stream calls fn with each user matching the filter in registration order,
+// reading them from the database one at a time
+func (r *UserRepository) Stream(filter domain.UserFilter, fn func(user domain.User) error) error {
+	rows, err := filterUsers(r.DB.Model(&domain.User{}), filter).Order("registered_at, id").Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var user domain.User
+		if err := r.DB.ScanRows(rows, &user); err != nil {
+			return err
+		}
+		if err := fn(user); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// filterUsers narrows query to the users matching filter
+func filterUsers(query *gorm.DB, filter domain.UserFilter) *gorm.DB {
+	if filter.Search != "" {
+		query = searchUsers(query, filter.Search)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Role != "" {
+		query = query.Where("role = ?", filter.Role)
+	}
+	if filter.Registration != "" {
+		query = query.Where("registration = ?", filter.Registration)
+	}
+	if filter.University != "" {
+		query = query.Where("university ILIKE ?", escapeLike(filter.University))
+	}
+	if filter.Faculty != "" {
+		query = query.Where("faculty ILIKE ?", escapeLike(filter.Faculty))
+	}
+	if filter.SizeJersey != "" {
+		query = query.Where("size_jersey = ?", filter.SizeJersey)
+	}
+	if filter.CheckedIn != nil {
+		if *filter.CheckedIn {
+			query = query.Where("last_entered IS NOT NULL")
+		} else {
+			query = query.Where("last_entered IS NULL")
+		}
+	}
+	if filter.RegisteredFrom != nil {
+		query = query.Where("registered_at >= ?", *filter.RegisteredFrom)
+	}
+	if filter.RegisteredTo != nil {
+		query = query.Where("registered_at < ?", *filter.RegisteredTo)
+	}
+	return query
+}
+
+// searchUsers narrows query to users whose name, email, phone number or UID
+// contains search. Phone numbers and UIDs are matched in the form they are
+// stored, so "+66 81 234" finds 081234... and "ab-12" finds AB12...
+func searchUsers(query *gorm.DB, search string) *gorm.DB {
+	phone, ok := utils.NormalizePhone(search)
+	if !ok {
+		phone = strings.NewReplacer(" ", "", "-", "").Replace(search)
+		if strings.HasPrefix(phone, "+66") {
+			phone = "0" + strings.TrimPrefix(phone[3:], "0")
+		}
+	}
+	pattern := "%" + escapeLike(search) + "%"
+	return query.Where(
+		"name ILIKE ? OR email ILIKE ? OR phone LIKE ? OR uid LIKE ?",
+		pattern, pattern, "%"+escapeLike(phone)+"%", "%"+escapeLike(utils.NormalizeUID(search))+"%",
+	)
+}
+
+// escapeLike escapes the characters LIKE treats as wildcards, so s only matches itself
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
 }
 
 func (r *UserRepository) GetByPhone(phone string) (domain.User, error) {
 	var user domain.User
 	err := r.DB.Where("phone = ?", phone).First(&user).Error
-	return user, err
+	return user, translateError(err, domain.ErrUserNotFound)
 }
 
-func (r *UserRepository) Update(id string, user *domain.User) error {
-	err := r.DB.Model(&domain.User{}).Where("id = ?", id).Updates(user).Error
-	return err
+func (r *UserRepository) GetByUID(uid string) (domain.User, error) {
+	var user domain.User
+	err := r.DB.Where("uid = ?", uid).First(&user).Error
+	return user, translateError(err, domain.ErrUserNotFound)
 }
 
-func (r *UserRepository) Delete(id string) error {
-	err := r.DB.Where("id = ?", id).Delete(&domain.User{}).Error
-	return err
+// Update writes the named fields of user, e.g. "Name" or "Email". Changing
+// "Status" moves the user to the new status's quota: they are confirmed if it
+// has room and waitlisted otherwise, and a place they held goes to the first
+// user on their old status's waitlist.
+func (r *UserRepository) Update(id string, user *domain.User, fields ...string) error {
+	if !slices.Contains(fields, "Status") {
+		return updateUser(r.DB, id, user, fields)
+	}
+
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		var current domain.User
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&current).Error
+		if err != nil {
+			return translateError(err, domain.ErrUserNotFound)
+		}
+		if current.Status == user.Status {
+			return updateUser(tx, id, user, fields)
+		}
+
+		// Lock both quotas in a fixed order so opposite moves cannot deadlock
+		statuses := []domain.Status{current.Status, user.Status}
+		slices.Sort(statuses)
+		for _, status := range statuses {
+			if _, err := lockQuota(tx, status); err != nil {
+				return err
+			}
+		}
+
+		if err := placeUser(tx, user); err != nil {
+			return err
+		}
+		if err := updateUser(tx, id, user, append(slices.Clip(fields), "Registration")); err != nil {
+			return err
+		}
+		if current.Registration == domain.RegistrationWaitlisted {
+			return nil
+		}
+		return promoteWaitlist(tx, current.Status)
+	})
 }
 
-func (r *UserRepository) IsUIDExists(uid string) (bool, error) {
-	var count int64
-	err := r.DB.Model(&domain.User{}).Where("uid = ?", uid).Count(&count).Error
-	if err != nil {
-		return false, err
+func updateUser(tx *gorm.DB, id string, user *domain.User, fields []string) error {
+	result := tx.Model(&domain.User{}).Where("id = ?", id).Select(fields).Updates(user)
+	if result.Error != nil {
+		return translateError(result.Error, nil)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrUserNotFound
 	}
-	return count > 0, nil
+	return nil
+}
+
+// Delete removes the user. If they held a place at the event it goes to the
+// first user on their status's waitlist.
+func (r *UserRepository) Delete(id string) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		var user domain.User
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&user).Error
+		if err != nil {
+			return translateError(err, domain.ErrUserNotFound)
+		}
+
+		if err := tx.Where("id = ?", id).Delete(&domain.User{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Save(&domain.UserTombstone{UserID: id, DeletedAt: time.Now()}).Error; err != nil {
+			return err
+		}
+		if user.Registration == domain.RegistrationWaitlisted {
+			return nil
+		}
+		return promoteWaitlist(tx, user.Status)
+	})
+}
+
+// GetUpdatedSince returns users created or changed at or after since
+func (r *UserRepository) GetUpdatedSince(since time.Time) ([]domain.User, error) {
+	var users []domain.User
+	err := r.DB.Where("updated_at >= ?", since).Find(&users).Error
+	return users, err
+}
+
+// GetDeletedSince returns the IDs of users deleted at or after since
+func (r *UserRepository) GetDeletedSince(since time.Time) ([]string, error) {
+	var ids []string
+	err := r.DB.Model(&domain.UserTombstone{}).Where("deleted_at >= ?", since).Pluck("user_id", &ids).Error
+	return ids, err
 }
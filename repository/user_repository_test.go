@@ -0,0 +1,100 @@
+package repository_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/isd-sgcu/cutu2025-backend/domain"
+	"github.com/isd-sgcu/cutu2025-backend/repository"
+	"gorm.io/gorm"
+)
+
+func TestUserRepositoryUpdateStatusMovesQuota(t *testing.T) {
+	db := openTestDB(t)
+	repo := repository.NewUserRepository(db)
+
+	// Start from empty alumni and general public places
+	if err := db.Where("status IN ?", []domain.Status{domain.StatusAlumni, domain.StatusGeneralPublic}).Delete(&domain.User{}).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Session(&gorm.Session{AllowGlobalUpdate: true}).Delete(&domain.RegistrationQuota{}).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Create(&domain.RegistrationQuota{Status: domain.StatusAlumni, Capacity: 1}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	alumnus := createTestUser(t, db)
+	alumnus.Status = domain.StatusAlumni
+	if err := repo.Update(alumnus.ID, &alumnus, "Status"); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	other := createTestUser(t, db)
+	other.Status = domain.StatusAlumni
+	if err := repo.Update(other.ID, &other, "Status"); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	registration := func(id string) domain.Registration {
+		t.Helper()
+		user, err := repo.GetById(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return user.Registration
+	}
+	if got := registration(alumnus.ID); got != domain.RegistrationConfirmed {
+		t.Fatalf("first alumnus is %s, want %s", got, domain.RegistrationConfirmed)
+	}
+	if got := registration(other.ID); got != domain.RegistrationWaitlisted {
+		t.Fatalf("second alumnus is %s, want %s", got, domain.RegistrationWaitlisted)
+	}
+
+	// Moving the confirmed alumnus away hands their place to the waitlist
+	alumnus.Status = domain.StatusGeneralPublic
+	if err := repo.Update(alumnus.ID, &alumnus, "Status"); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if got := registration(alumnus.ID); got != domain.RegistrationConfirmed {
+		t.Fatalf("moved user is %s, want %s", got, domain.RegistrationConfirmed)
+	}
+	if got := registration(other.ID); got != domain.RegistrationConfirmed {
+		t.Fatalf("waitlisted alumnus is %s after a place was freed, want %s", got, domain.RegistrationConfirmed)
+	}
+}
+
+func TestUserRepositoryCreateReportsTakenFields(t *testing.T) {
+	db := openTestDB(t)
+	repo := repository.NewUserRepository(db)
+	existing := createTestUser(t, db)
+
+	tests := []struct {
+		name    string
+		change  func(user *domain.User)
+		wantErr error
+	}{
+		{"UID", func(user *domain.User) { user.UID = existing.UID }, domain.ErrUIDTaken},
+		{"phone", func(user *domain.User) { user.Phone = existing.Phone }, domain.ErrPhoneTaken},
+		{"ID", func(user *domain.User) { user.ID = existing.ID }, domain.ErrConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := domain.User{
+				ID:           uuid.NewString(),
+				UID:          uuid.NewString(),
+				Name:         "Test Attendee",
+				Phone:        uuid.NewString()[:10],
+				Status:       domain.StatusGeneralPublic,
+				RegisteredAt: time.Now(),
+				Role:         domain.Member,
+			}
+			tt.change(&user)
+			if err := repo.Create(&user); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Create() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
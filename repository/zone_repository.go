@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"github.com/isd-sgcu/cutu2025-backend/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ZoneRepository struct {
+	DB *gorm.DB
+}
+
+func NewZoneRepository(db *gorm.DB) *ZoneRepository {
+	return &ZoneRepository{DB: db}
+}
+
+func (r *ZoneRepository) Create(zone *domain.Zone) error {
+	return translateError(r.DB.Create(zone).Error, nil)
+}
+
+func (r *ZoneRepository) GetAll() ([]domain.Zone, error) {
+	var zones []domain.Zone
+	err := r.DB.Order("name").Find(&zones).Error
+	return zones, err
+}
+
+func (r *ZoneRepository) GetById(id string) (domain.Zone, error) {
+	var zone domain.Zone
+	err := r.DB.Where("id = ?", id).First(&zone).Error
+	return zone, translateError(err, domain.ErrZoneNotFound)
+}
+
+func (r *ZoneRepository) Update(zone *domain.Zone) error {
+	return r.DB.Save(zone).Error
+}
+
+// Delete removes a zone, failing with domain.ErrZoneInUse while gates admit into it
+func (r *ZoneRepository) Delete(id string) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		// Lock the zone so no gate can be added to it while it is deleted
+		var zone domain.Zone
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&zone).Error
+		if err != nil {
+			return translateError(err, domain.ErrZoneNotFound)
+		}
+
+		var gates int64
+		if err := tx.Model(&domain.Gate{}).Where("zone_id = ?", id).Count(&gates).Error; err != nil {
+			return err
+		}
+		if gates > 0 {
+			return domain.ErrZoneInUse
+		}
+		return tx.Delete(&zone).Error
+	})
+}
+
+// CreateGate adds a gate, failing with domain.ErrZoneNotFound if its zone does not exist
+func (r *ZoneRepository) CreateGate(gate *domain.Gate) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		// Share-lock the zone so it cannot be deleted before the gate is stored
+		err := tx.Clauses(clause.Locking{Strength: "SHARE"}).Where("id = ?", gate.ZoneID).First(&domain.Zone{}).Error
+		if err != nil {
+			return translateError(err, domain.ErrZoneNotFound)
+		}
+		return translateError(tx.Create(gate).Error, nil)
+	})
+}
+
+func (r *ZoneRepository) GetAllGates() ([]domain.Gate, error) {
+	var gates []domain.Gate
+	err := r.DB.Order("id").Find(&gates).Error
+	return gates, err
+}
+
+func (r *ZoneRepository) GetGateById(id string) (domain.Gate, error) {
+	var gate domain.Gate
+	err := r.DB.Where("id = ?", id).First(&gate).Error
+	return gate, translateError(err, domain.ErrGateNotFound)
+}
+
+// DeleteGate deletes a gate and unassigns its staff
+func (r *ZoneRepository) DeleteGate(id string) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("id = ?", id).Delete(&domain.Gate{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrGateNotFound
+		}
+		return tx.Where("gate_id = ?", id).Delete(&domain.GateAssignment{}).Error
+	})
+}
+
+// AssignStaff places a staff member at a gate, replacing any previous assignment
+func (r *ZoneRepository) AssignStaff(assignment *domain.GateAssignment) error {
+	return r.DB.Clauses(clause.OnConflict{UpdateAll: true}).Create(assignment).Error
+}
+
+func (r *ZoneRepository) UnassignStaff(gateID, staffID string) error {
+	result := r.DB.Where("staff_id = ? AND gate_id = ?", staffID, gateID).Delete(&domain.GateAssignment{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrStaffNotAssigned
+	}
+	return nil
+}
+
+func (r *ZoneRepository) GetAssignment(staffID string) (domain.GateAssignment, error) {
+	var assignment domain.GateAssignment
+	err := r.DB.Where("staff_id = ?", staffID).First(&assignment).Error
+	return assignment, translateError(err, domain.ErrStaffNotAssigned)
+}
+
+func (r *ZoneRepository) GetGateStaff(gateID string) ([]domain.GateAssignment, error) {
+	var assignments []domain.GateAssignment
+	err := r.DB.Where("gate_id = ?", gateID).Order("assigned_at").Find(&assignments).Error
+	return assignments, err
+}
+
+// GetOccupancy counts the users currently inside, overall and per zone
+func (r *ZoneRepository) GetOccupancy() (domain.Occupancy, error) {
+	occupancy := domain.Occupancy{Zones: []domain.ZoneOccupancy{}}
+
+	if err := r.DB.Model(&domain.Presence{}).Where("inside").Count(&occupancy.Total).Error; err != nil {
+		return occupancy, err
+	}
+
+	err := r.DB.Model(&domain.Zone{}).
+		Select("zones.id AS zone_id, zones.name, zones.capacity, COUNT(presences.user_id) AS occupancy").
+		Joins("LEFT JOIN presences ON presences.zone_id = zones.id AND presences.inside").
+		Group("zones.id, zones.name, zones.capacity").
+		Order("zones.name").
+		Scan(&occupancy.Zones).Error
+	return occupancy, err
+}
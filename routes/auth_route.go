@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/isd-sgcu/cutu2025-backend/handler"
+	"github.com/isd-sgcu/cutu2025-backend/usecase"
+)
+
+func RegisterAuthRoutes(app *fiber.App, authUsecase *usecase.AuthUsecase) {
+	authHandler := handler.NewAuthHandler(authUsecase)
+
+	api := app.Group("/api/auth")
+
+	api.Post("/refresh", authHandler.Refresh)
+	api.Post("/logout", authHandler.Logout)
+}
@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/isd-sgcu/cutu2025-backend/domain"
+	"github.com/isd-sgcu/cutu2025-backend/handler"
+	"github.com/isd-sgcu/cutu2025-backend/middleware"
+	"github.com/isd-sgcu/cutu2025-backend/usecase"
+)
+
+func RegisterCheckInRoutes(app *fiber.App, checkInUsecase *usecase.CheckInUsecase, userUsecase *usecase.UserUsecase) {
+	checkInHandler := handler.NewCheckInHandler(checkInUsecase)
+
+	app.Post("/api/users/qr/:token", middleware.RoleMiddleware(
+		userUsecase,
+		domain.Staff,
+		domain.Admin,
+	),
+		checkInHandler.ScanQR)
+
+	api := app.Group("/api/checkins")
+
+	api.Get("/", middleware.RoleMiddleware(userUsecase, domain.Admin), checkInHandler.List)
+	api.Get("/lookup", middleware.RoleMiddleware(userUsecase, domain.Staff, domain.Admin), checkInHandler.Lookup)
+	api.Post("/manual", middleware.RoleMiddleware(userUsecase, domain.Staff, domain.Admin), checkInHandler.ManualCheckIn)
+	api.Post("/:id/void", middleware.RoleMiddleware(userUsecase, domain.Staff, domain.Admin), checkInHandler.Void)
+}
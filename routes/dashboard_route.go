@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/isd-sgcu/cutu2025-backend/domain"
+	"github.com/isd-sgcu/cutu2025-backend/handler"
+	"github.com/isd-sgcu/cutu2025-backend/middleware"
+	"github.com/isd-sgcu/cutu2025-backend/usecase"
+)
+
+func RegisterDashboardRoutes(app *fiber.App, dashboardUsecase *usecase.DashboardUsecase, authUsecase *usecase.AuthUsecase, userUsecase *usecase.UserUsecase) {
+	dashboardHandler := handler.NewDashboardHandler(dashboardUsecase, authUsecase)
+
+	dashboard := app.Group("/api/dashboard")
+
+	dashboard.Get("/stats", middleware.RoleMiddleware(userUsecase, domain.Admin), dashboardHandler.GetStats)
+	dashboard.Post("/stream-token", middleware.RoleMiddleware(userUsecase, domain.Admin), dashboardHandler.IssueStreamToken)
+	dashboard.Get("/stream", middleware.StreamRoleMiddleware(userUsecase, domain.Admin), dashboardHandler.Stream)
+}
@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/isd-sgcu/cutu2025-backend/domain"
+	"github.com/isd-sgcu/cutu2025-backend/handler"
+	"github.com/isd-sgcu/cutu2025-backend/middleware"
+	"github.com/isd-sgcu/cutu2025-backend/usecase"
+)
+
+func RegisterEventRoutes(app *fiber.App, eventUsecase *usecase.EventUsecase, userUsecase *usecase.UserUsecase) {
+	eventHandler := handler.NewEventHandler(eventUsecase)
+
+	events := app.Group("/api/events")
+
+	events.Get("/", middleware.RoleMiddleware(userUsecase, domain.Staff, domain.Admin), eventHandler.GetAll)
+	events.Post("/", middleware.RoleMiddleware(userUsecase, domain.Admin), eventHandler.Create)
+	events.Get("/:id", middleware.RoleMiddleware(userUsecase, domain.Staff, domain.Admin), eventHandler.GetById)
+	events.Delete("/:id", middleware.RoleMiddleware(userUsecase, domain.Admin), eventHandler.Delete)
+	events.Post("/:id/sessions", middleware.RoleMiddleware(userUsecase, domain.Admin), eventHandler.CreateSession)
+
+	sessions := app.Group("/api/sessions")
+
+	sessions.Get("/active", middleware.RoleMiddleware(userUsecase, domain.Staff, domain.Admin), eventHandler.GetActiveSession)
+	sessions.Patch("/:id", middleware.RoleMiddleware(userUsecase, domain.Admin), eventHandler.UpdateSession)
+	sessions.Delete("/:id", middleware.RoleMiddleware(userUsecase, domain.Admin), eventHandler.DeleteSession)
+}
@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/isd-sgcu/cutu2025-backend/domain"
+	"github.com/isd-sgcu/cutu2025-backend/handler"
+	"github.com/isd-sgcu/cutu2025-backend/middleware"
+	"github.com/isd-sgcu/cutu2025-backend/usecase"
+)
+
+func RegisterInvitationRoutes(app *fiber.App, invitationUsecase *usecase.InvitationUsecase, userUsecase *usecase.UserUsecase) {
+	invitationHandler := handler.NewInvitationHandler(invitationUsecase)
+
+	invitations := app.Group("/api/invitations")
+
+	invitations.Get("/", middleware.RoleMiddleware(userUsecase, domain.Admin), invitationHandler.GetAll)
+	invitations.Post("/", middleware.RoleMiddleware(userUsecase, domain.Admin), invitationHandler.CreateBatch)
+	invitations.Get("/redemptions", middleware.RoleMiddleware(userUsecase, domain.Admin), invitationHandler.GetRedemptions)
+	invitations.Get("/:code/redemptions", middleware.RoleMiddleware(userUsecase, domain.Admin), invitationHandler.GetRedemptions)
+	invitations.Post("/:code/revoke", middleware.RoleMiddleware(userUsecase, domain.Admin), invitationHandler.Revoke)
+}
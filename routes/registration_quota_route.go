@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/isd-sgcu/cutu2025-backend/domain"
+	"github.com/isd-sgcu/cutu2025-backend/handler"
+	"github.com/isd-sgcu/cutu2025-backend/middleware"
+	"github.com/isd-sgcu/cutu2025-backend/usecase"
+)
+
+func RegisterRegistrationQuotaRoutes(app *fiber.App, quotaUsecase *usecase.RegistrationQuotaUsecase, userUsecase *usecase.UserUsecase) {
+	quotaHandler := handler.NewRegistrationQuotaHandler(quotaUsecase)
+
+	quotas := app.Group("/api/quotas")
+
+	quotas.Get("/", middleware.RoleMiddleware(userUsecase, domain.Admin), quotaHandler.GetUsage)
+	quotas.Put("/:status", middleware.RoleMiddleware(userUsecase, domain.Admin), quotaHandler.Set)
+	quotas.Delete("/:status", middleware.RoleMiddleware(userUsecase, domain.Admin), quotaHandler.Delete)
+
+	app.Get("/api/waitlist/me", middleware.AuthMiddleware(userUsecase), quotaHandler.GetMyPosition)
+}
@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/isd-sgcu/cutu2025-backend/domain"
+	"github.com/isd-sgcu/cutu2025-backend/handler"
+	"github.com/isd-sgcu/cutu2025-backend/middleware"
+	"github.com/isd-sgcu/cutu2025-backend/usecase"
+)
+
+func RegisterRoleGrantRoutes(app *fiber.App, roleGrantUsecase *usecase.RoleGrantUsecase, userUsecase *usecase.UserUsecase) {
+	roleGrantHandler := handler.NewRoleGrantHandler(roleGrantUsecase)
+
+	grants := app.Group("/api/roles/grants")
+
+	grants.Get("/", middleware.RoleMiddleware(userUsecase, domain.Admin), roleGrantHandler.GetAll)
+	grants.Post("/", middleware.RoleMiddleware(userUsecase, domain.Admin), roleGrantHandler.Create)
+	grants.Delete("/:id", middleware.RoleMiddleware(userUsecase, domain.Admin), roleGrantHandler.Delete)
+}
@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/isd-sgcu/cutu2025-backend/domain"
+	"github.com/isd-sgcu/cutu2025-backend/handler"
+	"github.com/isd-sgcu/cutu2025-backend/middleware"
+	"github.com/isd-sgcu/cutu2025-backend/usecase"
+)
+
+func RegisterScannerRoutes(app *fiber.App, scannerUsecase *usecase.ScannerUsecase, userUsecase *usecase.UserUsecase) {
+	scannerHandler := handler.NewScannerHandler(scannerUsecase)
+
+	scanner := app.Group("/api/scanner")
+
+	scanner.Get("/key", middleware.RoleMiddleware(userUsecase, domain.Staff, domain.Admin), scannerHandler.GetKey)
+	scanner.Get("/snapshot", middleware.RoleMiddleware(userUsecase, domain.Staff, domain.Admin), scannerHandler.Snapshot)
+	scanner.Post("/sync", middleware.RoleMiddleware(userUsecase, domain.Staff, domain.Admin), scannerHandler.Sync)
+}
@@ -23,17 +23,12 @@ func RegisterUserRoutes(app *fiber.App, userUsecase *usecase.UserUsecase) {
 		),
 		userHandler.GetAll)
 
+	api.Get("/export", middleware.RoleMiddleware(userUsecase, domain.Admin), userHandler.Export)
 	api.Get("/:id", middleware.AuthMiddleware(userUsecase), userHandler.GetById)
 	api.Get("image/:id", middleware.RoleMiddleware(userUsecase, domain.Admin), userHandler.GetImageURL)
 
-	api.Post("/qr/:id", middleware.RoleMiddleware(
-		userUsecase,
-		domain.Staff,
-		domain.Admin,
-	),
-		userHandler.ScanQR)
-
 	api.Get("/qr/:id", middleware.AuthMiddleware(userUsecase), userHandler.GetQRURL)
+	api.Get("/qr/:id/image", middleware.AuthMiddleware(userUsecase), userHandler.GetQRImage)
 
 	api.Post("/register", userHandler.Register)
 
@@ -44,6 +39,7 @@ func RegisterUserRoutes(app *fiber.App, userUsecase *usecase.UserUsecase) {
 		userHandler.Update)
 
 	api.Patch("/", middleware.AuthMiddleware(userUsecase), userHandler.UpdateMyAccountInfo)
+	api.Delete("/", middleware.AuthMiddleware(userUsecase), userHandler.CancelMyRegistration)
 	api.Patch("/addstaff/:phone", middleware.RoleMiddleware(userUsecase, domain.Admin), userHandler.AddStaff)
 	api.Delete("/:id", middleware.RoleMiddleware(userUsecase, domain.Admin), userHandler.Delete)
 	api.Patch("/role/:id", middleware.RoleMiddleware(userUsecase, domain.Admin), userHandler.UpdateRole)
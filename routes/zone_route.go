@@ -0,0 +1,31 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/isd-sgcu/cutu2025-backend/domain"
+	"github.com/isd-sgcu/cutu2025-backend/handler"
+	"github.com/isd-sgcu/cutu2025-backend/middleware"
+	"github.com/isd-sgcu/cutu2025-backend/usecase"
+)
+
+func RegisterZoneRoutes(app *fiber.App, zoneUsecase *usecase.ZoneUsecase, userUsecase *usecase.UserUsecase) {
+	zoneHandler := handler.NewZoneHandler(zoneUsecase)
+
+	zones := app.Group("/api/zones")
+
+	zones.Get("/", middleware.RoleMiddleware(userUsecase, domain.Staff, domain.Admin), zoneHandler.GetAll)
+	zones.Post("/", middleware.RoleMiddleware(userUsecase, domain.Admin), zoneHandler.Create)
+	zones.Patch("/:id", middleware.RoleMiddleware(userUsecase, domain.Admin), zoneHandler.Update)
+	zones.Delete("/:id", middleware.RoleMiddleware(userUsecase, domain.Admin), zoneHandler.Delete)
+
+	gates := app.Group("/api/gates")
+
+	gates.Get("/", middleware.RoleMiddleware(userUsecase, domain.Staff, domain.Admin), zoneHandler.GetAllGates)
+	gates.Post("/", middleware.RoleMiddleware(userUsecase, domain.Admin), zoneHandler.CreateGate)
+	gates.Delete("/:id", middleware.RoleMiddleware(userUsecase, domain.Admin), zoneHandler.DeleteGate)
+	gates.Get("/:id/staff", middleware.RoleMiddleware(userUsecase, domain.Staff, domain.Admin), zoneHandler.GetGateStaff)
+	gates.Put("/:id/staff/:staffId", middleware.RoleMiddleware(userUsecase, domain.Admin), zoneHandler.AssignStaff)
+	gates.Delete("/:id/staff/:staffId", middleware.RoleMiddleware(userUsecase, domain.Admin), zoneHandler.UnassignStaff)
+
+	app.Get("/api/occupancy", middleware.RoleMiddleware(userUsecase, domain.Staff, domain.Admin), zoneHandler.GetOccupancy)
+}
@@ -0,0 +1,139 @@
+package usecase
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/isd-sgcu/cutu2025-backend/domain"
+	"github.com/isd-sgcu/cutu2025-backend/utils"
+)
+
+// streamTokenTTL only needs to cover opening the stream; it is not checked again afterwards
+const streamTokenTTL = time.Minute
+
+type AuthUsecase struct {
+	Repo            RefreshTokenRepositoryInterface
+	UserRepo        UserRepositoryInterface
+	JWTSecret       string
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+}
+
+type RefreshTokenRepositoryInterface interface {
+	Create(token *domain.RefreshToken) error
+	GetByHash(hash string) (domain.RefreshToken, error)
+	MarkUsed(id string) (bool, error)
+	RevokeFamily(familyID string) error
+	RevokeByUserId(userID string) error
+}
+
+func NewAuthUsecase(repo RefreshTokenRepositoryInterface, userRepo UserRepositoryInterface, jwtSecret string, accessTokenTTL, refreshTokenTTL time.Duration) *AuthUsecase {
+	return &AuthUsecase{
+		Repo:            repo,
+		UserRepo:        userRepo,
+		JWTSecret:       jwtSecret,
+		AccessTokenTTL:  accessTokenTTL,
+		RefreshTokenTTL: refreshTokenTTL,
+	}
+}
+
+// IssueTokens starts a new refresh token family for the user, e.g. on sign-in
+func (a *AuthUsecase) IssueTokens(userID string) (domain.TokenResponse, error) {
+	return a.issueTokens(userID, uuid.NewString())
+}
+
+// IssueStreamToken creates a token the user can open the dashboard stream with
+func (a *AuthUsecase) IssueStreamToken(userID string) (domain.StreamTokenResponse, error) {
+	token, expiresAt, err := utils.GenerateStreamToken(userID, a.JWTSecret, streamTokenTTL)
+	if err != nil {
+		return domain.StreamTokenResponse{}, fmt.Errorf("error generating stream token: %w", err)
+	}
+	return domain.StreamTokenResponse{Token: token, ExpiresAt: expiresAt}, nil
+}
+
+func (a *AuthUsecase) issueTokens(userID, familyID string) (domain.TokenResponse, error) {
+	accessToken, expiresAt, err := utils.GenerateAccessToken(userID, a.JWTSecret, a.AccessTokenTTL)
+	if err != nil {
+		return domain.TokenResponse{}, fmt.Errorf("error generating access token: %w", err)
+	}
+
+	refreshToken, err := utils.GenerateRefreshToken()
+	if err != nil {
+		return domain.TokenResponse{}, fmt.Errorf("error generating refresh token: %w", err)
+	}
+
+	now := time.Now()
+	if err := a.Repo.Create(&domain.RefreshToken{
+		ID:        uuid.NewString(),
+		FamilyID:  familyID,
+		UserID:    userID,
+		TokenHash: utils.HashToken(refreshToken),
+		ExpiresAt: now.Add(a.RefreshTokenTTL),
+		CreatedAt: now,
+	}); err != nil {
+		return domain.TokenResponse{}, fmt.Errorf("error saving refresh token: %w", err)
+	}
+
+	return domain.TokenResponse{
+		UserID:       userID,
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresAt:    expiresAt,
+	}, nil
+}
+
+// Refresh exchanges a refresh token for a new token pair. Presenting a token
+// that was already rotated revokes its whole family, since either the client
+// or an attacker holds a stolen copy.
+func (a *AuthUsecase) Refresh(refreshToken string) (domain.TokenResponse, error) {
+	stored, err := a.Repo.GetByHash(utils.HashToken(refreshToken))
+	if err != nil {
+		return domain.TokenResponse{}, err
+	}
+
+	if stored.RevokedAt != nil || time.Now().After(stored.ExpiresAt) {
+		return domain.TokenResponse{}, domain.ErrInvalidRefreshToken
+	}
+
+	if stored.UsedAt != nil {
+		return domain.TokenResponse{}, a.revokeReusedFamily(stored.FamilyID)
+	}
+
+	marked, err := a.Repo.MarkUsed(stored.ID)
+	if err != nil {
+		return domain.TokenResponse{}, err
+	}
+	if !marked {
+		// Lost a race against another refresh with the same token
+		return domain.TokenResponse{}, a.revokeReusedFamily(stored.FamilyID)
+	}
+
+	if _, err := a.UserRepo.GetById(stored.UserID); err != nil {
+		return domain.TokenResponse{}, domain.ErrInvalidRefreshToken
+	}
+
+	return a.issueTokens(stored.UserID, stored.FamilyID)
+}
+
+func (a *AuthUsecase) revokeReusedFamily(familyID string) error {
+	if err := a.Repo.RevokeFamily(familyID); err != nil {
+		return err
+	}
+	return domain.ErrRefreshTokenReused
+}
+
+// Logout revokes the refresh token family the given token belongs to
+func (a *AuthUsecase) Logout(refreshToken string) error {
+	stored, err := a.Repo.GetByHash(utils.HashToken(refreshToken))
+	if err != nil {
+		return err
+	}
+
+	return a.Repo.RevokeFamily(stored.FamilyID)
+}
+
+// RevokeAll revokes every refresh token of a user, e.g. after a role change
+func (a *AuthUsecase) RevokeAll(userID string) error {
+	return a.Repo.RevokeByUserId(userID)
+}
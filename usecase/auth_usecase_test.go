@@ -0,0 +1,201 @@
+package usecase_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/isd-sgcu/cutu2025-backend/domain"
+	"github.com/isd-sgcu/cutu2025-backend/usecase"
+	"github.com/isd-sgcu/cutu2025-backend/utils"
+)
+
+// fakeRefreshTokenRepo keeps refresh tokens in memory, keyed by hash
+type fakeRefreshTokenRepo struct {
+	tokens map[string]*domain.RefreshToken
+}
+
+func newFakeRefreshTokenRepo() *fakeRefreshTokenRepo {
+	return &fakeRefreshTokenRepo{tokens: map[string]*domain.RefreshToken{}}
+}
+
+func (r *fakeRefreshTokenRepo) Create(token *domain.RefreshToken) error {
+	stored := *token
+	r.tokens[token.TokenHash] = &stored
+	return nil
+}
+
+func (r *fakeRefreshTokenRepo) GetByHash(hash string) (domain.RefreshToken, error) {
+	token, ok := r.tokens[hash]
+	if !ok {
+		return domain.RefreshToken{}, domain.ErrInvalidRefreshToken
+	}
+	return *token, nil
+}
+
+func (r *fakeRefreshTokenRepo) MarkUsed(id string) (bool, error) {
+	for _, token := range r.tokens {
+		if token.ID == id && token.UsedAt == nil && token.RevokedAt == nil {
+			now := time.Now()
+			token.UsedAt = &now
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *fakeRefreshTokenRepo) RevokeFamily(familyID string) error {
+	for _, token := range r.tokens {
+		if token.FamilyID == familyID && token.RevokedAt == nil {
+			now := time.Now()
+			token.RevokedAt = &now
+		}
+	}
+	return nil
+}
+
+func (r *fakeRefreshTokenRepo) RevokeByUserId(userID string) error {
+	for _, token := range r.tokens {
+		if token.UserID == userID && token.RevokedAt == nil {
+			now := time.Now()
+			token.RevokedAt = &now
+		}
+	}
+	return nil
+}
+
+// get returns the stored record of a refresh token
+func (r *fakeRefreshTokenRepo) get(t *testing.T, refreshToken string) *domain.RefreshToken {
+	t.Helper()
+	token, ok := r.tokens[utils.HashToken(refreshToken)]
+	if !ok {
+		t.Fatalf("refresh token %q was not stored", refreshToken)
+	}
+	return token
+}
+
+// fakeUserRepo only knows the users it is given
+type fakeUserRepo struct {
+	usecase.UserRepositoryInterface
+	users map[string]domain.User
+}
+
+func (r *fakeUserRepo) GetById(id string) (domain.User, error) {
+	user, ok := r.users[id]
+	if !ok {
+		return domain.User{}, domain.ErrUserNotFound
+	}
+	return user, nil
+}
+
+func newTestAuthUsecase(t *testing.T) (*usecase.AuthUsecase, *fakeRefreshTokenRepo, domain.TokenResponse) {
+	t.Helper()
+	repo := newFakeRefreshTokenRepo()
+	users := &fakeUserRepo{users: map[string]domain.User{testUserID: {ID: testUserID}}}
+	u := usecase.NewAuthUsecase(repo, users, "test-secret", 15*time.Minute, 24*time.Hour)
+
+	tokens, err := u.IssueTokens(testUserID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return u, repo, tokens
+}
+
+func TestAuthUsecaseRefreshRotates(t *testing.T) {
+	u, repo, first := newTestAuthUsecase(t)
+
+	second, err := u.Refresh(first.RefreshToken)
+	if err != nil {
+		t.Fatalf("Refresh() error = %v, want nil", err)
+	}
+	if second.RefreshToken == first.RefreshToken || second.UserID != testUserID {
+		t.Fatalf("Refresh() = %+v, want a new refresh token for %s", second, testUserID)
+	}
+	if repo.get(t, first.RefreshToken).UsedAt == nil {
+		t.Fatal("the rotated refresh token was not marked as used")
+	}
+	if repo.get(t, second.RefreshToken).FamilyID != repo.get(t, first.RefreshToken).FamilyID {
+		t.Fatal("the new refresh token is not in the family of the one it replaced")
+	}
+
+	// The new token can be rotated in turn
+	if _, err := u.Refresh(second.RefreshToken); err != nil {
+		t.Fatalf("Refresh() of the new token error = %v, want nil", err)
+	}
+}
+
+func TestAuthUsecaseRefreshReuseRevokesFamily(t *testing.T) {
+	u, repo, first := newTestAuthUsecase(t)
+
+	second, err := u.Refresh(first.RefreshToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A stolen copy of the first token is presented after the client rotated it
+	if _, err := u.Refresh(first.RefreshToken); !errors.Is(err, domain.ErrRefreshTokenReused) {
+		t.Fatalf("Refresh() of a rotated token error = %v, want %v", err, domain.ErrRefreshTokenReused)
+	}
+	if repo.get(t, second.RefreshToken).RevokedAt == nil {
+		t.Fatal("the family was not revoked after reuse")
+	}
+	if _, err := u.Refresh(second.RefreshToken); !errors.Is(err, domain.ErrInvalidRefreshToken) {
+		t.Fatalf("Refresh() of a token in a revoked family error = %v, want %v", err, domain.ErrInvalidRefreshToken)
+	}
+
+	// Other sign-ins of the user are not affected
+	other, err := u.IssueTokens(testUserID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := u.Refresh(other.RefreshToken); err != nil {
+		t.Fatalf("Refresh() of another family error = %v, want nil", err)
+	}
+}
+
+func TestAuthUsecaseRefreshRejectsInvalidTokens(t *testing.T) {
+	tests := []struct {
+		name      string
+		presented string // The issued token if empty
+		prepare   func(stored *domain.RefreshToken)
+	}{
+		{"expired", "", func(stored *domain.RefreshToken) {
+			stored.ExpiresAt = time.Now().Add(-time.Second)
+		}},
+		{"revoked on logout", "", func(stored *domain.RefreshToken) {
+			now := time.Now()
+			stored.RevokedAt = &now
+		}},
+		{"unknown", "not-a-refresh-token", func(*domain.RefreshToken) {}},
+		{"user deleted", "", func(stored *domain.RefreshToken) {
+			stored.UserID = "U0000000000000000"
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, repo, tokens := newTestAuthUsecase(t)
+			tt.prepare(repo.get(t, tokens.RefreshToken))
+			presented := tt.presented
+			if presented == "" {
+				presented = tokens.RefreshToken
+			}
+
+			if _, err := u.Refresh(presented); !errors.Is(err, domain.ErrInvalidRefreshToken) {
+				t.Fatalf("Refresh() error = %v, want %v", err, domain.ErrInvalidRefreshToken)
+			}
+		})
+	}
+}
+
+func TestAuthUsecaseRefreshExpiredTokenIsNotUsedUp(t *testing.T) {
+	u, repo, tokens := newTestAuthUsecase(t)
+	repo.get(t, tokens.RefreshToken).ExpiresAt = time.Now().Add(-time.Second)
+
+	if _, err := u.Refresh(tokens.RefreshToken); !errors.Is(err, domain.ErrInvalidRefreshToken) {
+		t.Fatalf("Refresh() error = %v, want %v", err, domain.ErrInvalidRefreshToken)
+	}
+	if repo.get(t, tokens.RefreshToken).UsedAt != nil {
+		t.Fatal("an expired refresh token was marked as used")
+	}
+}
@@ -0,0 +1,438 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/isd-sgcu/cutu2025-backend/domain"
+	"github.com/isd-sgcu/cutu2025-backend/utils"
+)
+
+type CheckInUsecase struct {
+	Repo        CheckInRepositoryInterface
+	UserRepo    UserRepositoryInterface
+	EventRepo   EventRepositoryInterface
+	ZoneRepo    ZoneRepositoryInterface
+	QRSigner    QRTokenSignerInterface
+	QrNonceRepo QrNonceRepositoryInterface
+	Events      CheckInEventPublisherInterface // Optional; nil disables live updates
+	Timezone    *time.Location                 // Days are counted in it when no sessions are configured
+}
+
+type CheckInRepositoryInterface interface {
+	Create(checkIn *domain.CheckIn) error
+	List(filter domain.CheckInFilter) ([]domain.CheckIn, error)
+	GetById(id string) (domain.CheckIn, error)
+	Void(id, voidedBy, reason string, at time.Time) (domain.CheckIn, error)
+	Admit(checkIn *domain.CheckIn, nonce *domain.QrNonce, admit func(user domain.User, last *domain.CheckIn, presence *domain.Presence) error) (domain.User, error)
+}
+
+type CheckInEventPublisherInterface interface {
+	Publish(event domain.CheckInEvent) error
+}
+
+type QrNonceRepositoryInterface interface {
+	DeleteExpired(before time.Time) error
+}
+
+func NewCheckInUsecase(
+	repo CheckInRepositoryInterface,
+	userRepo UserRepositoryInterface,
+	eventRepo EventRepositoryInterface,
+	zoneRepo ZoneRepositoryInterface,
+	qrSigner QRTokenSignerInterface,
+	qrNonceRepo QrNonceRepositoryInterface,
+) *CheckInUsecase {
+	return &CheckInUsecase{
+		Repo:        repo,
+		UserRepo:    userRepo,
+		EventRepo:   eventRepo,
+		ZoneRepo:    zoneRepo,
+		QRSigner:    qrSigner,
+		QrNonceRepo: qrNonceRepo,
+		Timezone:    time.Local,
+	}
+}
+
+// admitEntry applies the session's entry policy given the user's last accepted scan in that session
+func admitEntry(policy domain.EntryPolicy, last *domain.CheckIn) error {
+	if last == nil {
+		return nil
+	}
+
+	switch policy {
+	case domain.EntryReentry:
+		return nil
+	case domain.EntryPaired:
+		if last.Direction == domain.DirectionExit {
+			return nil
+		}
+	}
+	return domain.ErrUserAlreadyEntered
+}
+
+// admitUnscheduled is used when no sessions have been configured: users may
+// enter at any time, once per day in loc
+func admitUnscheduled(user domain.User, at time.Time, loc *time.Location) error {
+	if user.LastEntered != nil && isSameDay(*user.LastEntered, at, loc) {
+		return domain.ErrUserAlreadyEntered
+	}
+	return nil
+}
+
+// isSameDay reports whether t1 and t2 fall on the same day in loc, whichever
+// locations they are expressed in
+func isSameDay(t1, t2 time.Time, loc *time.Location) bool {
+	y1, m1, d1 := t1.In(loc).Date()
+	y2, m2, d2 := t2.In(loc).Date()
+	return y1 == y2 && m1 == m2 && d1 == d2
+}
+
+// admitExit only lets users out who are recorded as inside
+func admitExit(presence *domain.Presence) error {
+	if presence == nil || !presence.Inside {
+		return domain.ErrUserNotInside
+	}
+	return nil
+}
+
+// ScanQR checks the user identified by a QR token in or out. Every attempt,
+// successful or not, is recorded in the check-in log.
+func (u *CheckInUsecase) ScanQR(token string, scan domain.ScanContext) (domain.ScanResult, error) {
+	checkIn, err := newCheckIn(scan, domain.SourceOnline)
+	if err != nil {
+		return domain.ScanResult{}, err
+	}
+
+	user, err := u.scanQR(token, checkIn)
+	return u.record(checkIn, user, err), err
+}
+
+func (u *CheckInUsecase) scanQR(token string, checkIn *domain.CheckIn) (domain.User, error) {
+	nonce, err := u.verifyQRToken(token, checkIn.ScannedAt)
+	if err != nil {
+		return domain.User{}, err
+	}
+	checkIn.UserID = nonce.UserID
+
+	return u.admit(checkIn, nonce)
+}
+
+// AdmitToken decides a scan of a QR token made at checkIn.ScannedAt, e.g. by a
+// device that was offline, and records it if accepted. The token must have been
+// valid when it was scanned and must identify checkIn.UserID, if set. Scans
+// that are not accepted are left for the caller to log.
+func (u *CheckInUsecase) AdmitToken(checkIn *domain.CheckIn, token string) (domain.User, error) {
+	nonce, err := u.verifyQRToken(token, checkIn.ScannedAt)
+	if err != nil {
+		return domain.User{}, err
+	}
+	if checkIn.UserID != "" && checkIn.UserID != nonce.UserID {
+		return domain.User{}, domain.ErrInvalidQRToken
+	}
+	checkIn.UserID = nonce.UserID
+
+	return u.admit(checkIn, nonce)
+}
+
+// ManualCheckIn checks a user in or out without a QR code, e.g. when their phone
+// is dead. It is decided exactly like a scan and logged with the manual source.
+func (u *CheckInUsecase) ManualCheckIn(userID string, scan domain.ScanContext) (domain.ScanResult, error) {
+	checkIn, err := newCheckIn(scan, domain.SourceManual)
+	if err != nil {
+		return domain.ScanResult{}, err
+	}
+	checkIn.UserID = userID
+
+	user, err := u.Admit(checkIn)
+	return u.record(checkIn, user, err), err
+}
+
+// LookupAttendee finds an attendee by UID or phone so staff can verify them against their photo
+func (u *CheckInUsecase) LookupAttendee(uid, phone string) (domain.GateAttendee, error) {
+	var user domain.User
+	var err error
+	switch {
+	case uid != "":
+		uid = utils.NormalizeUID(uid)
+		user, err = u.UserRepo.GetByUID(uid)
+		// UIDs issued before check characters were added have none, so only
+		// blame a typo once the lookup has failed
+		if errors.Is(err, domain.ErrUserNotFound) && !utils.ValidateUID(uid) {
+			err = domain.ErrInvalidUID
+		}
+	case phone != "":
+		phone = strings.TrimSpace(phone)
+		if normalized, ok := utils.NormalizePhone(phone); ok {
+			phone = normalized
+		}
+		user, err = u.UserRepo.GetByPhone(phone)
+	default:
+		err = domain.ErrUserNotFound
+	}
+	if err != nil {
+		return domain.GateAttendee{}, err
+	}
+	return domain.NewGateAttendee(user), nil
+}
+
+func newCheckIn(scan domain.ScanContext, source domain.CheckInSource) (*domain.CheckIn, error) {
+	if scan.Direction == "" {
+		scan.Direction = domain.DirectionEntry
+	}
+	if scan.Direction != domain.DirectionEntry && scan.Direction != domain.DirectionExit {
+		return nil, domain.ErrInvalidDirection
+	}
+
+	return &domain.CheckIn{
+		ID:        uuid.NewString(),
+		ScannerID: scan.ScannerID,
+		Gate:      scan.Gate,
+		DeviceID:  scan.DeviceID,
+		Direction: scan.Direction,
+		Source:    source,
+		ScannedAt: time.Now(),
+	}, nil
+}
+
+// record logs the outcome of a scan, pushes it to live dashboards and returns
+// what the scanning device is shown
+func (u *CheckInUsecase) record(checkIn *domain.CheckIn, user domain.User, err error) domain.ScanResult {
+	checkIn.Result, checkIn.Reason = scanOutcome(err)
+
+	// Accepted scans are recorded atomically with the decision; log the rest here.
+	// The gate decision has been made, so a failure to log it must not reverse it.
+	if checkIn.Result != domain.CheckInAccepted {
+		if logErr := u.Repo.Create(checkIn); logErr != nil {
+			log.Printf("Failed to record check-in %s: %v", checkIn.ID, logErr)
+		}
+	}
+	publishCheckIn(u.Events, checkIn, user)
+
+	return domain.ScanResult{
+		GateAttendee: domain.NewGateAttendee(user),
+		CheckInID:    checkIn.ID,
+		Verdict:      checkIn.Result,
+		Reason:       checkIn.Reason,
+	}
+}
+
+// Admit decides a scan of checkIn.UserID made at checkIn.ScannedAt and records
+// it if accepted. Scans that are not accepted are left for the caller to log.
+func (u *CheckInUsecase) Admit(checkIn *domain.CheckIn) (domain.User, error) {
+	return u.admit(checkIn, nil)
+}
+
+// admit is Admit for a scanned QR code, whose nonce is used up only if the scan is accepted
+func (u *CheckInUsecase) admit(checkIn *domain.CheckIn, nonce *domain.QrNonce) (domain.User, error) {
+	zone, err := u.scanZone(checkIn)
+	// Gates whose zone is gone admit no one but still let people out
+	if err != nil && !(checkIn.Direction == domain.DirectionExit && errors.Is(err, domain.ErrZoneAccessDenied)) {
+		return domain.User{}, err
+	}
+
+	if checkIn.Direction == domain.DirectionExit {
+		// Let people out even after the session has closed
+		if session, err := u.EventRepo.GetActiveSession(checkIn.ScannedAt); err == nil {
+			checkIn.SessionID = session.ID
+		}
+		return u.Repo.Admit(checkIn, nonce, func(_ domain.User, _ *domain.CheckIn, presence *domain.Presence) error {
+			return admitExit(presence)
+		})
+	}
+
+	session, err := u.EventRepo.GetActiveSession(checkIn.ScannedAt)
+	scheduled := err == nil
+	if errors.Is(err, domain.ErrNoActiveSession) {
+		// Events without any sessions are not restricted to session windows
+		hasSessions, hasErr := u.EventRepo.HasSessions()
+		if hasErr != nil {
+			return domain.User{}, hasErr
+		}
+		if !hasSessions {
+			err = nil
+		}
+	}
+	if err != nil {
+		return domain.User{}, err
+	}
+	checkIn.SessionID = session.ID
+
+	return u.Repo.Admit(checkIn, nonce, func(user domain.User, last *domain.CheckIn, _ *domain.Presence) error {
+		if user.Registration == domain.RegistrationWaitlisted {
+			return domain.ErrUserWaitlisted
+		}
+		if zone != nil && !zone.Permits(user) {
+			return domain.ErrZoneAccessDenied
+		}
+		if !scheduled {
+			return admitUnscheduled(user, checkIn.ScannedAt, u.Timezone)
+		}
+		return admitEntry(session.EntryPolicy, last)
+	})
+}
+
+// scanZone resolves the gate and zone a scan was made at. Staff assigned to a
+// gate always scan at that gate; otherwise the gate reported by the scanner is
+// used, and must be registered. Scans without a gate have no zone but still
+// count towards overall occupancy; they fail with domain.ErrStaffNotAssigned
+// once any zone restricts who may enter, as they would bypass its rules. Gates
+// whose zone has been deleted fail with domain.ErrZoneAccessDenied.
+func (u *CheckInUsecase) scanZone(checkIn *domain.CheckIn) (*domain.Zone, error) {
+	assignment, err := u.ZoneRepo.GetAssignment(checkIn.ScannerID)
+	if err == nil {
+		checkIn.Gate = assignment.GateID
+	} else if !errors.Is(err, domain.ErrStaffNotAssigned) {
+		return nil, err
+	}
+
+	if checkIn.Gate == "" {
+		restricted, err := u.hasRestrictedZones()
+		if err != nil {
+			return nil, err
+		}
+		if restricted {
+			return nil, domain.ErrStaffNotAssigned
+		}
+		return nil, nil
+	}
+	gate, err := u.ZoneRepo.GetGateById(checkIn.Gate)
+	if err != nil {
+		return nil, err
+	}
+	checkIn.ZoneID = gate.ZoneID
+
+	zone, err := u.ZoneRepo.GetById(gate.ZoneID)
+	if errors.Is(err, domain.ErrZoneNotFound) {
+		return nil, domain.ErrZoneAccessDenied
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &zone, nil
+}
+
+// hasRestrictedZones reports whether any zone only admits some users
+func (u *CheckInUsecase) hasRestrictedZones() (bool, error) {
+	zones, err := u.ZoneRepo.GetAll()
+	if err != nil {
+		return false, err
+	}
+	for _, zone := range zones {
+		if zone.Restricted() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// verifyQRToken checks a QR token scanned at the given time and returns its
+// nonce. The nonce is consumed when the scan is accepted, so the same code
+// cannot be used to enter twice, while a code turned away (e.g. at the wrong
+// gate) can still be used.
+func (u *CheckInUsecase) verifyQRToken(token string, at time.Time) (*domain.QrNonce, error) {
+	claims, err := u.QRSigner.VerifyAt(token, at)
+	if err != nil {
+		if errors.Is(err, utils.ErrQRTokenExpired) {
+			return nil, domain.ErrQRTokenExpired
+		}
+		return nil, domain.ErrInvalidQRToken
+	}
+
+	return &domain.QrNonce{
+		Nonce:     claims.Nonce,
+		UserID:    claims.UserID,
+		ExpiresAt: claims.ExpiresAt,
+	}, nil
+}
+
+// PurgeExpiredNonces deletes the nonces of QR codes that can no longer be
+// scanned, every interval until ctx is done
+func (u *CheckInUsecase) PurgeExpiredNonces(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// Keep nonces a while past expiry to allow for scanner clock skew
+			if err := u.QrNonceRepo.DeleteExpired(time.Now().Add(-time.Hour)); err != nil {
+				log.Printf("Failed to delete expired QR nonces: %v", err)
+			}
+		}
+	}
+}
+
+// publishCheckIn pushes a recorded scan to live dashboards. Delivery is best
+// effort; the check-in log remains the source of truth.
+func publishCheckIn(events CheckInEventPublisherInterface, checkIn *domain.CheckIn, user domain.User) {
+	if events == nil {
+		return
+	}
+	err := events.Publish(domain.CheckInEvent{
+		CheckIn:  *checkIn,
+		UserName: user.Name,
+		Status:   user.Status,
+	})
+	if err != nil {
+		log.Printf("Failed to publish check-in %s: %v", checkIn.ID, err)
+	}
+}
+
+// scanOutcome maps the result of a scan to what is recorded in the check-in log
+func scanOutcome(err error) (domain.CheckInResult, string) {
+	switch {
+	case err == nil:
+		return domain.CheckInAccepted, ""
+	case errors.Is(err, domain.ErrUserAlreadyEntered):
+		return domain.CheckInDuplicate, domain.ReasonAlreadyEntered
+	case errors.Is(err, domain.ErrInvalidQRToken):
+		return domain.CheckInRejected, domain.ReasonInvalidQR
+	case errors.Is(err, domain.ErrQRTokenExpired):
+		return domain.CheckInRejected, domain.ReasonExpiredQR
+	case errors.Is(err, domain.ErrQRTokenReplayed):
+		return domain.CheckInRejected, domain.ReasonReplayedQR
+	case errors.Is(err, domain.ErrUserNotFound):
+		return domain.CheckInRejected, domain.ReasonUserNotFound
+	case errors.Is(err, domain.ErrNoActiveSession):
+		return domain.CheckInRejected, domain.ReasonNoSession
+	case errors.Is(err, domain.ErrZoneFull):
+		return domain.CheckInRejected, domain.ReasonZoneFull
+	case errors.Is(err, domain.ErrUserNotInside):
+		return domain.CheckInRejected, domain.ReasonNotInside
+	case errors.Is(err, domain.ErrZoneAccessDenied):
+		return domain.CheckInRejected, domain.ReasonNotPermitted
+	case errors.Is(err, domain.ErrGateNotFound), errors.Is(err, domain.ErrStaffNotAssigned):
+		return domain.CheckInRejected, domain.ReasonUnknownGate
+	case errors.Is(err, domain.ErrUserWaitlisted):
+		return domain.CheckInRejected, domain.ReasonWaitlisted
+	}
+	return domain.CheckInRejected, domain.ReasonInternalError
+}
+
+// Void reverts a mistaken check-in so the user can be scanned again
+func (u *CheckInUsecase) Void(id, voidedBy, reason string) (domain.CheckIn, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return domain.CheckIn{}, domain.ErrInvalidVoidReason
+	}
+
+	checkIn, err := u.Repo.Void(id, voidedBy, reason, time.Now())
+	if err != nil {
+		return domain.CheckIn{}, err
+	}
+
+	user, _ := u.UserRepo.GetById(checkIn.UserID)
+	publishCheckIn(u.Events, &checkIn, user)
+
+	return checkIn, nil
+}
+
+func (u *CheckInUsecase) List(filter domain.CheckInFilter) ([]domain.CheckIn, error) {
+	return u.Repo.List(filter)
+}
@@ -0,0 +1,271 @@
+package usecase_test
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/isd-sgcu/cutu2025-backend/domain"
+	"github.com/isd-sgcu/cutu2025-backend/usecase"
+	"github.com/isd-sgcu/cutu2025-backend/utils"
+)
+
+const testUserID = "U1234567890abcdef"
+
+// fakeCheckInRepo keeps check-ins in memory. Admit follows the contract of
+// the Postgres repository: the user is locked, the nonce is consumed and the
+// decision is made under the lock, and the nonce is released again if the
+// scan is refused.
+type fakeCheckInRepo struct {
+	usecase.CheckInRepositoryInterface
+
+	userLock sync.Mutex // Stands in for the row lock on the user
+
+	mu        sync.Mutex // Guards the fields below
+	user      domain.User
+	nonces    map[string]bool
+	accepted  []domain.CheckIn
+	logged    []domain.CheckIn
+	nonceless int // Scans admitted without a nonce to consume
+}
+
+func newFakeCheckInRepo(user domain.User) *fakeCheckInRepo {
+	return &fakeCheckInRepo{user: user, nonces: map[string]bool{}}
+}
+
+func (r *fakeCheckInRepo) Create(checkIn *domain.CheckIn) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.logged = append(r.logged, *checkIn)
+	return nil
+}
+
+func (r *fakeCheckInRepo) Admit(checkIn *domain.CheckIn, nonce *domain.QrNonce, admit func(user domain.User, last *domain.CheckIn, presence *domain.Presence) error) (domain.User, error) {
+	r.userLock.Lock()
+	defer r.userLock.Unlock()
+
+	r.mu.Lock()
+	if checkIn.UserID != r.user.ID {
+		r.mu.Unlock()
+		return domain.User{}, domain.ErrUserNotFound
+	}
+	if nonce == nil {
+		r.nonceless++
+	} else {
+		if r.nonces[nonce.Nonce] {
+			r.mu.Unlock()
+			return domain.User{}, domain.ErrQRTokenReplayed
+		}
+		r.nonces[nonce.Nonce] = true
+	}
+	var last *domain.CheckIn
+	for i := range r.accepted {
+		if r.accepted[i].SessionID == checkIn.SessionID {
+			last = &r.accepted[i]
+		}
+	}
+	user := r.user
+	r.mu.Unlock()
+
+	// Give other scans a chance to run while this one is being decided
+	time.Sleep(time.Millisecond)
+	err := admit(user, last, nil)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err != nil {
+		if nonce != nil {
+			delete(r.nonces, nonce.Nonce)
+		}
+		return domain.User{}, err
+	}
+	checkIn.Result = domain.CheckInAccepted
+	r.accepted = append(r.accepted, *checkIn)
+	if nonce != nil {
+		r.user.QrSeq++
+	}
+	return r.user, nil
+}
+
+type fakeEventRepo struct {
+	usecase.EventRepositoryInterface
+	session *domain.Session // nil when no sessions are configured
+}
+
+func (r *fakeEventRepo) GetActiveSession(time.Time) (domain.Session, error) {
+	if r.session == nil {
+		return domain.Session{}, domain.ErrNoActiveSession
+	}
+	return *r.session, nil
+}
+
+func (r *fakeEventRepo) HasSessions() (bool, error) {
+	return r.session != nil, nil
+}
+
+// fakeZoneRepo has no zones, gates or assignments
+type fakeZoneRepo struct {
+	usecase.ZoneRepositoryInterface
+}
+
+func (fakeZoneRepo) GetAssignment(string) (domain.GateAssignment, error) {
+	return domain.GateAssignment{}, domain.ErrStaffNotAssigned
+}
+
+func (fakeZoneRepo) GetAll() ([]domain.Zone, error) {
+	return nil, nil
+}
+
+func newTestCheckInUsecase(repo *fakeCheckInRepo, events *fakeEventRepo) (*usecase.CheckInUsecase, *utils.QRTokenSigner) {
+	signer := utils.NewQRTokenSigner([]utils.QRKey{{ID: "k1", Secret: []byte("secret")}}, time.Hour)
+	return usecase.NewCheckInUsecase(repo, nil, events, fakeZoneRepo{}, signer, nil), signer
+}
+
+// scanAll scans every token at once from a different gate and returns the results
+func scanAll(t *testing.T, u *usecase.CheckInUsecase, tokens []string) []domain.ScanResult {
+	t.Helper()
+
+	results := make([]domain.ScanResult, len(tokens))
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	for i, token := range tokens {
+		wg.Add(1)
+		go func(i int, token string) {
+			defer wg.Done()
+			<-start
+			results[i], _ = u.ScanQR(token, domain.ScanContext{
+				ScannerID: fmt.Sprintf("staff-%d", i),
+				Direction: domain.DirectionEntry,
+			})
+		}(i, token)
+	}
+	close(start)
+	wg.Wait()
+	return results
+}
+
+func countVerdicts(results []domain.ScanResult) map[string]int {
+	counts := map[string]int{}
+	for _, result := range results {
+		counts[string(result.Verdict)+"/"+result.Reason]++
+	}
+	return counts
+}
+
+func TestCheckInUsecaseScanQRSameCodeConcurrently(t *testing.T) {
+	repo := newFakeCheckInRepo(domain.User{ID: testUserID, Registration: domain.RegistrationConfirmed})
+	u, signer := newTestCheckInUsecase(repo, &fakeEventRepo{
+		session: &domain.Session{ID: "session-1", EntryPolicy: domain.EntryReentry},
+	})
+	token, _, err := signer.Sign(testUserID, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Even where re-entry is allowed, one code only lets its holder in once
+	const scans = 20
+	tokens := make([]string, scans)
+	for i := range tokens {
+		tokens[i] = token
+	}
+	counts := countVerdicts(scanAll(t, u, tokens))
+
+	want := map[string]int{
+		string(domain.CheckInAccepted) + "/":                           1,
+		string(domain.CheckInRejected) + "/" + domain.ReasonReplayedQR: scans - 1,
+	}
+	if fmt.Sprint(counts) != fmt.Sprint(want) {
+		t.Fatalf("verdicts = %v, want %v", counts, want)
+	}
+	if len(repo.logged) != scans-1 {
+		t.Fatalf("logged %d refused scans, want %d", len(repo.logged), scans-1)
+	}
+	// The nonce must be consumed under the user's lock, not before
+	if repo.nonceless != 0 {
+		t.Fatalf("%d scans were admitted without their nonce", repo.nonceless)
+	}
+}
+
+func TestCheckInUsecaseScanQRDifferentCodesConcurrently(t *testing.T) {
+	repo := newFakeCheckInRepo(domain.User{ID: testUserID, Registration: domain.RegistrationConfirmed})
+	u, signer := newTestCheckInUsecase(repo, &fakeEventRepo{
+		session: &domain.Session{ID: "session-1", EntryPolicy: domain.EntrySingle},
+	})
+
+	// Codes shared with friends: each is valid, but the user may enter once
+	const scans = 20
+	tokens := make([]string, scans)
+	for i := range tokens {
+		token, _, err := signer.Sign(testUserID, i)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tokens[i] = token
+	}
+	counts := countVerdicts(scanAll(t, u, tokens))
+
+	want := map[string]int{
+		string(domain.CheckInAccepted) + "/":                                1,
+		string(domain.CheckInDuplicate) + "/" + domain.ReasonAlreadyEntered: scans - 1,
+	}
+	if fmt.Sprint(counts) != fmt.Sprint(want) {
+		t.Fatalf("verdicts = %v, want %v", counts, want)
+	}
+	// Codes turned away are not used up
+	if len(repo.nonces) != 1 {
+		t.Fatalf("%d nonces were consumed, want 1", len(repo.nonces))
+	}
+	// The nonce must be consumed under the user's lock, not before
+	if repo.nonceless != 0 {
+		t.Fatalf("%d scans were admitted without their nonce", repo.nonceless)
+	}
+}
+
+func TestCheckInUsecaseAdmitUnscheduledCountsDaysInEventTimezone(t *testing.T) {
+	bangkok := time.FixedZone("ICT", 7*60*60)
+	at := func(value string) time.Time {
+		t.Helper()
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return parsed
+	}
+
+	tests := []struct {
+		name        string
+		lastEntered time.Time
+		scannedAt   time.Time
+		wantErr     error
+	}{
+		{"past midnight in Bangkok, same day in UTC", at("2025-02-15T16:30:00Z"), at("2025-02-15T17:30:00Z"), nil},
+		{"same day in Bangkok, previous day in UTC", at("2025-02-14T23:30:00Z"), at("2025-02-15T10:00:00Z"), domain.ErrUserAlreadyEntered},
+		{"different days in Bangkok, in different locations", at("2025-02-15T23:30:00+07:00"), at("2025-02-15T17:30:00Z"), nil},
+		{"same day in Bangkok, in different locations", at("2025-02-16T00:30:00+07:00"), at("2025-02-15T17:45:00Z"), domain.ErrUserAlreadyEntered},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lastEntered := tt.lastEntered
+			repo := newFakeCheckInRepo(domain.User{
+				ID:           testUserID,
+				Registration: domain.RegistrationConfirmed,
+				LastEntered:  &lastEntered,
+			})
+			u, _ := newTestCheckInUsecase(repo, &fakeEventRepo{})
+			u.Timezone = bangkok
+
+			_, err := u.Admit(&domain.CheckIn{
+				UserID:    testUserID,
+				Direction: domain.DirectionEntry,
+				Source:    domain.SourceManual,
+				ScannedAt: tt.scannedAt,
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Admit() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/isd-sgcu/cutu2025-backend/domain"
+)
+
+// DashboardWindow is how far back the rolling aggregates look
+const DashboardWindow = time.Hour
+
+type DashboardUsecase struct {
+	Repo   DashboardRepositoryInterface
+	Events CheckInEventBrokerInterface
+}
+
+type DashboardRepositoryInterface interface {
+	GetEntryStats(from time.Time) (domain.DashboardStats, error)
+}
+
+// CheckInEventBrokerInterface is implemented by the in-process broker and by
+// the Redis one used when several instances run behind a load balancer
+type CheckInEventBrokerInterface interface {
+	CheckInEventPublisherInterface
+	Subscribe() (<-chan domain.CheckInEvent, func())
+}
+
+func NewDashboardUsecase(repo DashboardRepositoryInterface, events CheckInEventBrokerInterface) *DashboardUsecase {
+	return &DashboardUsecase{Repo: repo, Events: events}
+}
+
+// GetStats aggregates accepted entries over the last DashboardWindow
+func (u *DashboardUsecase) GetStats() (domain.DashboardStats, error) {
+	return u.Repo.GetEntryStats(time.Now().Add(-DashboardWindow).Truncate(time.Minute))
+}
+
+// Subscribe streams check-in events until the returned function is called
+func (u *DashboardUsecase) Subscribe() (<-chan domain.CheckInEvent, func()) {
+	return u.Events.Subscribe()
+}
@@ -0,0 +1,145 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/isd-sgcu/cutu2025-backend/domain"
+)
+
+type EventUsecase struct {
+	Repo EventRepositoryInterface
+}
+
+type EventRepositoryInterface interface {
+	Create(event *domain.Event) error
+	GetAll() ([]domain.Event, error)
+	GetById(id string) (domain.Event, error)
+	Delete(id string) error
+	CreateSession(session *domain.Session) error
+	GetSessionById(id string) (domain.Session, error)
+	UpdateSession(session *domain.Session) error
+	DeleteSession(id string) error
+	GetActiveSession(t time.Time) (domain.Session, error)
+	HasSessions() (bool, error)
+	HasOverlappingSession(start, end time.Time, excludeID string) (bool, error)
+}
+
+func NewEventUsecase(repo EventRepositoryInterface) *EventUsecase {
+	return &EventUsecase{Repo: repo}
+}
+
+func (u *EventUsecase) Create(event *domain.Event) error {
+	if event.Name == "" {
+		return domain.ErrInvalidEvent
+	}
+	if _, err := time.LoadLocation(event.Timezone); err != nil || event.Timezone == "" {
+		return domain.ErrInvalidEvent
+	}
+
+	event.ID = uuid.NewString()
+	event.CreatedAt = time.Now()
+	event.Sessions = nil
+	return u.Repo.Create(event)
+}
+
+func (u *EventUsecase) GetAll() ([]domain.Event, error) {
+	events, err := u.Repo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	for i := range events {
+		localizeSessions(&events[i])
+	}
+	return events, nil
+}
+
+func (u *EventUsecase) GetById(id string) (domain.Event, error) {
+	event, err := u.Repo.GetById(id)
+	if err != nil {
+		return domain.Event{}, err
+	}
+	localizeSessions(&event)
+	return event, nil
+}
+
+func (u *EventUsecase) Delete(id string) error {
+	return u.Repo.Delete(id)
+}
+
+func (u *EventUsecase) CreateSession(eventID string, session *domain.Session) error {
+	if _, err := u.Repo.GetById(eventID); err != nil {
+		return err
+	}
+
+	session.ID = uuid.NewString()
+	session.EventID = eventID
+	if err := u.validateSession(session); err != nil {
+		return err
+	}
+	return u.Repo.CreateSession(session)
+}
+
+// UpdateSession applies the fields present in req to the session
+func (u *EventUsecase) UpdateSession(id string, req domain.UpdateSessionRequest) error {
+	session, err := u.Repo.GetSessionById(id)
+	if err != nil {
+		return err
+	}
+
+	if req.Name != nil {
+		session.Name = *req.Name
+	}
+	if req.StartsAt != nil {
+		session.StartsAt = *req.StartsAt
+	}
+	if req.EndsAt != nil {
+		session.EndsAt = *req.EndsAt
+	}
+	if req.EntryPolicy != nil {
+		session.EntryPolicy = *req.EntryPolicy
+	}
+
+	if err := u.validateSession(&session); err != nil {
+		return err
+	}
+	return u.Repo.UpdateSession(&session)
+}
+
+func (u *EventUsecase) DeleteSession(id string) error {
+	return u.Repo.DeleteSession(id)
+}
+
+// GetActiveSession returns the session gates are currently admitting to
+func (u *EventUsecase) GetActiveSession() (domain.Session, error) {
+	return u.Repo.GetActiveSession(time.Now())
+}
+
+// validateSession checks a session's window and policy; sessions may not
+// overlap, so that at most one session is open at any moment
+func (u *EventUsecase) validateSession(session *domain.Session) error {
+	if session.Name == "" || !session.EntryPolicy.IsValid() || !session.StartsAt.Before(session.EndsAt) {
+		return domain.ErrInvalidSession
+	}
+
+	overlaps, err := u.Repo.HasOverlappingSession(session.StartsAt, session.EndsAt, session.ID)
+	if err != nil {
+		return err
+	}
+	if overlaps {
+		return domain.ErrSessionOverlap
+	}
+	return nil
+}
+
+// localizeSessions expresses session times in the event's timezone
+func localizeSessions(event *domain.Event) {
+	loc, err := time.LoadLocation(event.Timezone)
+	if err != nil {
+		return
+	}
+	for i := range event.Sessions {
+		event.Sessions[i].StartsAt = event.Sessions[i].StartsAt.In(loc)
+		event.Sessions[i].EndsAt = event.Sessions[i].EndsAt.In(loc)
+	}
+}
@@ -0,0 +1,151 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/isd-sgcu/cutu2025-backend/domain"
+	"github.com/isd-sgcu/cutu2025-backend/utils"
+)
+
+const (
+	invitationCodeLength = 8
+	maxInvitationBatch   = 1000
+)
+
+type InvitationUsecase struct {
+	Repo InvitationRepositoryInterface
+}
+
+type InvitationRepositoryInterface interface {
+	CreateBatch(invitations []domain.Invitation) error
+	GetAll(batchID string) ([]domain.Invitation, error)
+	GetByCode(code string) (domain.Invitation, error)
+	Revoke(code string, at time.Time) error
+	Redeem(code string, user *domain.User, at time.Time, redeem func(invitation domain.Invitation) error) error
+	GetRedemptions(code, batchID string) ([]domain.RedemptionDetail, error)
+}
+
+func NewInvitationUsecase(repo InvitationRepositoryInterface) *InvitationUsecase {
+	return &InvitationUsecase{Repo: repo}
+}
+
+// CreateBatch generates req.Count codes that share a batch ID and what they grant
+func (u *InvitationUsecase) CreateBatch(req domain.CreateInvitationsRequest, createdBy string) ([]domain.Invitation, error) {
+	now := time.Now()
+	if req.Count < 1 || req.Count > maxInvitationBatch || req.MaxUses < 1 ||
+		(req.ExpiresAt != nil && !req.ExpiresAt.After(now)) ||
+		(req.Status != nil && !req.Status.IsValid()) {
+		return nil, domain.ErrInvalidInvitation
+	}
+
+	batchID := uuid.NewString()
+	invitations := make([]domain.Invitation, 0, req.Count)
+	seen := make(map[string]bool, req.Count)
+	for len(invitations) < req.Count {
+		code, err := utils.GenerateCode(invitationCodeLength)
+		if err != nil {
+			return nil, err
+		}
+		if seen[code] {
+			continue
+		}
+		seen[code] = true
+		invitations = append(invitations, domain.Invitation{
+			Code:       code,
+			BatchID:    batchID,
+			Label:      strings.TrimSpace(req.Label),
+			MaxUses:    req.MaxUses,
+			ExpiresAt:  req.ExpiresAt,
+			Status:     req.Status,
+			TicketType: strings.TrimSpace(req.TicketType),
+			CreatedBy:  createdBy,
+			CreatedAt:  now,
+		})
+	}
+
+	if err := u.Repo.CreateBatch(invitations); err != nil {
+		return nil, err
+	}
+	return invitations, nil
+}
+
+func (u *InvitationUsecase) GetAll(batchID string) ([]domain.Invitation, error) {
+	return u.Repo.GetAll(batchID)
+}
+
+// GetRedemptions lists who redeemed a code, or every code in a batch when code is empty
+func (u *InvitationUsecase) GetRedemptions(code, batchID string) ([]domain.RedemptionDetail, error) {
+	if code != "" {
+		code = normalizeInvitationCode(&code)
+		if _, err := u.Repo.GetByCode(code); err != nil {
+			return nil, err
+		}
+	}
+	return u.Repo.GetRedemptions(code, batchID)
+}
+
+// Revoke stops a code from being redeemed. Users who already redeemed it keep
+// what it granted.
+func (u *InvitationUsecase) Revoke(code string) error {
+	return u.Repo.Revoke(normalizeInvitationCode(&code), time.Now())
+}
+
+func normalizeInvitationCode(code *string) string {
+	if code == nil {
+		return ""
+	}
+	return strings.ToUpper(strings.TrimSpace(*code))
+}
+
+// checkInvitation reports why an invitation cannot be redeemed at now, if it cannot
+func checkInvitation(invitation domain.Invitation, now time.Time) error {
+	switch {
+	case invitation.RevokedAt != nil:
+		return domain.ErrInvitationRevoked
+	case invitation.ExpiresAt != nil && !now.Before(*invitation.ExpiresAt):
+		return domain.ErrInvitationExpired
+	case invitation.Uses >= invitation.MaxUses:
+		return domain.ErrInvitationUsedUp
+	}
+	return nil
+}
+
+// invitationCodeError reports an invitation code that cannot be redeemed as an
+// invalid invitationCode field. Other errors are returned unchanged.
+func invitationCodeError(err error) error {
+	var message string
+	switch {
+	case errors.Is(err, domain.ErrInvitationNotFound):
+		message = "is not a valid invitation code"
+	case errors.Is(err, domain.ErrInvitationExpired):
+		message = "has expired"
+	case errors.Is(err, domain.ErrInvitationRevoked):
+		message = "has been revoked"
+	case errors.Is(err, domain.ErrInvitationUsedUp):
+		message = "has been used up"
+	default:
+		return err
+	}
+	verr := &domain.ValidationError{}
+	verr.Add("invitationCode", "invitation", message)
+	return verr
+}
+
+// redeemInvitation gives user what the invitation grants. A status it grants
+// may require fields the user did not have to give for the status they
+// registered with, so the profile is checked again.
+func redeemInvitation(invitation domain.Invitation, user *domain.User) error {
+	if err := checkInvitation(invitation, user.RegisteredAt); err != nil {
+		return err
+	}
+	if invitation.Status != nil {
+		user.Status = *invitation.Status
+	}
+	if invitation.TicketType != "" {
+		user.TicketType = invitation.TicketType
+	}
+	return user.CheckProfile()
+}
@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/isd-sgcu/cutu2025-backend/domain"
+)
+
+type RegistrationQuotaUsecase struct {
+	Repo RegistrationQuotaRepositoryInterface
+}
+
+type RegistrationQuotaRepositoryInterface interface {
+	Set(quota *domain.RegistrationQuota) error
+	Delete(status domain.Status) error
+	GetUsage() ([]domain.QuotaUsage, error)
+	GetWaitlistPosition(userID string) (domain.WaitlistPosition, error)
+}
+
+func NewRegistrationQuotaUsecase(repo RegistrationQuotaRepositoryInterface) *RegistrationQuotaUsecase {
+	return &RegistrationQuotaUsecase{Repo: repo}
+}
+
+// Set caps how many users of a status get a place. Waitlisted users are
+// promoted into any places a higher capacity opens up.
+func (u *RegistrationQuotaUsecase) Set(quota *domain.RegistrationQuota) error {
+	if !quota.Status.IsValid() || quota.Capacity < 0 {
+		return domain.ErrInvalidQuota
+	}
+	quota.UpdatedAt = time.Now()
+	return u.Repo.Set(quota)
+}
+
+// Delete makes a status unlimited again, confirming everyone waiting for it
+func (u *RegistrationQuotaUsecase) Delete(status domain.Status) error {
+	return u.Repo.Delete(status)
+}
+
+func (u *RegistrationQuotaUsecase) GetUsage() ([]domain.QuotaUsage, error) {
+	return u.Repo.GetUsage()
+}
+
+func (u *RegistrationQuotaUsecase) GetWaitlistPosition(userID string) (domain.WaitlistPosition, error) {
+	return u.Repo.GetWaitlistPosition(userID)
+}
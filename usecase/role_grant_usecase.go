@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/isd-sgcu/cutu2025-backend/domain"
+)
+
+type RoleGrantUsecase struct {
+	Repo     RoleGrantRepositoryInterface
+	UserRepo UserRepositoryInterface
+	Users    RoleUpdaterInterface
+}
+
+type RoleGrantRepositoryInterface interface {
+	Upsert(grant *domain.RoleGrant) error
+	GetAll() ([]domain.RoleGrant, error)
+	Delete(id string) error
+	GetForUser(lineID string) ([]domain.RoleGrant, error)
+	HasAdmin() (bool, error)
+}
+
+type RoleUpdaterInterface interface {
+	UpdateRole(id string, role domain.Role) error
+}
+
+func NewRoleGrantUsecase(repo RoleGrantRepositoryInterface, userRepo UserRepositoryInterface, users RoleUpdaterInterface) *RoleGrantUsecase {
+	return &RoleGrantUsecase{Repo: repo, UserRepo: userRepo, Users: users}
+}
+
+// Create grants a role to a LIFF ID. A user who has already registered with it
+// is given the role straight away.
+func (u *RoleGrantUsecase) Create(grant *domain.RoleGrant, createdBy string) error {
+	grant.Value = strings.TrimSpace(grant.Value)
+	if grant.Value == "" || !grant.Role.IsValid() || grant.Type != domain.GrantByLineID {
+		return domain.ErrInvalidRoleGrant
+	}
+
+	grant.ID = uuid.NewString()
+	grant.CreatedBy = createdBy
+	grant.CreatedAt = time.Now()
+	if err := u.Repo.Upsert(grant); err != nil {
+		return err
+	}
+
+	user, err := u.UserRepo.GetById(grant.Value)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return u.Users.UpdateRole(user.ID, grant.Role)
+}
+
+func (u *RoleGrantUsecase) GetAll() ([]domain.RoleGrant, error) {
+	return u.Repo.GetAll()
+}
+
+// Delete removes a grant. Users who already hold the role keep it.
+func (u *RoleGrantUsecase) Delete(id string) error {
+	return u.Repo.Delete(id)
+}
+
+// Bootstrap grants admin to the configured LIFF ID, but only while nobody is
+// admin yet, so the first organiser can sign in and take over
+func (u *RoleGrantUsecase) Bootstrap(lineID string) error {
+	if lineID == "" {
+		return nil
+	}
+	hasAdmin, err := u.Repo.HasAdmin()
+	if err != nil || hasAdmin {
+		return err
+	}
+
+	grant := domain.RoleGrant{Type: domain.GrantByLineID, Value: lineID, Role: domain.Admin}
+	if err := u.Create(&grant, ""); err != nil {
+		return err
+	}
+	log.Printf("Granted bootstrap admin to %s %s", grant.Type, grant.Value)
+	return nil
+}
+
+// grantedRole is the highest role granted to a user, or Member if none is
+func grantedRole(grants []domain.RoleGrant) domain.Role {
+	role := domain.Member
+	for _, grant := range grants {
+		if grant.Role == domain.Admin || (grant.Role == domain.Staff && role == domain.Member) {
+			role = grant.Role
+		}
+	}
+	return role
+}
@@ -0,0 +1,305 @@
+package usecase
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/isd-sgcu/cutu2025-backend/domain"
+)
+
+const (
+	// snapshotCursorOverlap re-sends changes committed around the time the cursor was taken
+	snapshotCursorOverlap = 5 * time.Second
+	// maxSyncBatch is the most scans a device may upload at once
+	maxSyncBatch = 500
+	// offlineClockSkew tolerates device clocks running ahead of the server
+	offlineClockSkew = time.Minute
+	// cursorSeparator separates the rules version from the time in a snapshot cursor
+	cursorSeparator = "~"
+)
+
+// ScannerUsecase serves gate devices that keep scanning while offline: they
+// download a signed attendee snapshot, then upload the scans they made.
+type ScannerUsecase struct {
+	UserRepo    UserRepositoryInterface
+	EventRepo   EventRepositoryInterface
+	ZoneRepo    ZoneRepositoryInterface
+	CheckInRepo CheckInRepositoryInterface
+	Admitter    CheckInAdmitterInterface
+	Signer      SnapshotSignerInterface
+	Events      CheckInEventPublisherInterface // Optional; nil disables live updates
+}
+
+type CheckInAdmitterInterface interface {
+	AdmitToken(checkIn *domain.CheckIn, token string) (domain.User, error)
+}
+
+type SnapshotSignerInterface interface {
+	Sign(payload []byte) string
+	PublicKey() string
+	KeyID() string
+}
+
+func NewScannerUsecase(
+	userRepo UserRepositoryInterface,
+	eventRepo EventRepositoryInterface,
+	zoneRepo ZoneRepositoryInterface,
+	checkInRepo CheckInRepositoryInterface,
+	admitter CheckInAdmitterInterface,
+	signer SnapshotSignerInterface,
+) *ScannerUsecase {
+	return &ScannerUsecase{
+		UserRepo:    userRepo,
+		EventRepo:   eventRepo,
+		ZoneRepo:    zoneRepo,
+		CheckInRepo: checkInRepo,
+		Admitter:    admitter,
+		Signer:      signer,
+	}
+}
+
+func (u *ScannerUsecase) GetKey() domain.SnapshotKey {
+	return domain.SnapshotKey{KeyID: u.Signer.KeyID(), PublicKey: u.Signer.PublicKey()}
+}
+
+// Snapshot returns the attendees the scanner may admit for the session. Without
+// a cursor every eligible attendee is returned; with one, only changes since.
+// Changes to the session or the zone's rules alter who is eligible without
+// touching the attendees, so a cursor taken under other rules gets a full snapshot.
+func (u *ScannerUsecase) Snapshot(sessionID, scannerID, cursor string) (domain.SignedSnapshot, error) {
+	session, err := u.EventRepo.GetSessionById(sessionID)
+	if err != nil {
+		return domain.SignedSnapshot{}, err
+	}
+	zone, err := u.scannerZone(scannerID)
+	if err != nil {
+		return domain.SignedSnapshot{}, err
+	}
+	rulesVersion, err := snapshotRulesVersion(session, zone)
+	if err != nil {
+		return domain.SignedSnapshot{}, err
+	}
+
+	generatedAt := time.Now()
+	snapshot := domain.Snapshot{
+		Session:      session,
+		RulesVersion: rulesVersion,
+		GeneratedAt:  generatedAt,
+		Cursor:       rulesVersion + cursorSeparator + generatedAt.UTC().Format(time.RFC3339Nano),
+		Full:         true,
+		Attendees:    []domain.SnapshotAttendee{},
+		Removed:      []string{},
+	}
+
+	var since time.Time
+	if cursor != "" {
+		version, at, ok := strings.Cut(cursor, cursorSeparator)
+		var parseErr error
+		if since, parseErr = time.Parse(time.RFC3339Nano, at); !ok || parseErr != nil {
+			return domain.SignedSnapshot{}, domain.ErrInvalidCursor
+		}
+		snapshot.Full = version != rulesVersion
+	}
+
+	var users []domain.User
+	if snapshot.Full {
+		users, err = u.UserRepo.GetAll()
+	} else {
+		since = since.Add(-snapshotCursorOverlap)
+
+		if users, err = u.UserRepo.GetUpdatedSince(since); err == nil {
+			var removed []string
+			if removed, err = u.UserRepo.GetDeletedSince(since); err == nil && removed != nil {
+				snapshot.Removed = removed
+			}
+		}
+	}
+	if err != nil {
+		return domain.SignedSnapshot{}, err
+	}
+
+	for _, user := range users {
+		eligible := user.Registration != domain.RegistrationWaitlisted && (zone == nil || zone.Permits(user))
+		// A full snapshot only needs attendees who can get in; a delta also
+		// tells devices about attendees who no longer can
+		if snapshot.Full && !eligible {
+			continue
+		}
+		snapshot.Attendees = append(snapshot.Attendees, domain.SnapshotAttendee{
+			ID:          user.ID,
+			UID:         user.UID,
+			Name:        user.Name,
+			Status:      user.Status,
+			TicketType:  user.TicketType,
+			PhotoHash:   photoHash(user.ImageURL),
+			LastEntered: user.LastEntered,
+			Eligible:    eligible,
+		})
+	}
+
+	payload, err := json.Marshal(snapshot)
+	if err != nil {
+		return domain.SignedSnapshot{}, err
+	}
+	return domain.SignedSnapshot{
+		Payload:   payload,
+		Signature: u.Signer.Sign(payload),
+		KeyID:     u.Signer.KeyID(),
+	}, nil
+}
+
+// Sync reconciles scans a device made while offline, oldest first. Each scan is
+// decided as if it had been made online at its scannedAt; scans the device let
+// through that the server would have refused are recorded as conflicts.
+func (u *ScannerUsecase) Sync(scannerID string, req domain.SyncRequest) (domain.SyncResponse, error) {
+	if len(req.Scans) > maxSyncBatch {
+		return domain.SyncResponse{}, domain.ErrInvalidSyncBatch
+	}
+
+	scans := slices.Clone(req.Scans)
+	slices.SortStableFunc(scans, func(a, b domain.OfflineScan) int {
+		return a.ScannedAt.Compare(b.ScannedAt)
+	})
+
+	response := domain.SyncResponse{Results: make([]domain.SyncResult, 0, len(scans))}
+	for _, scan := range scans {
+		response.Results = append(response.Results, u.reconcile(scannerID, req.DeviceID, scan))
+	}
+	return response, nil
+}
+
+func (u *ScannerUsecase) reconcile(scannerID, deviceID string, scan domain.OfflineScan) domain.SyncResult {
+	result := domain.SyncResult{ID: scan.ID}
+
+	// Devices retry uploads, so a scan that is already recorded keeps its outcome
+	existing, err := u.CheckInRepo.GetById(scan.ID)
+	if err == nil {
+		result.Result, result.Reason = existing.Result, existing.Reason
+		return result
+	}
+	if !errors.Is(err, domain.ErrCheckInNotFound) {
+		result.Result, result.Reason = domain.CheckInRejected, domain.ReasonInternalError
+		return result
+	}
+
+	if scan.Direction == "" {
+		scan.Direction = domain.DirectionEntry
+	}
+	if scan.ID == "" || scan.ScannedAt.IsZero() ||
+		(scan.Admitted && scan.Token == "") || (!scan.Admitted && scan.UserID == "") ||
+		scan.ScannedAt.After(time.Now().Add(offlineClockSkew)) ||
+		(scan.Direction != domain.DirectionEntry && scan.Direction != domain.DirectionExit) {
+		result.Result, result.Reason = domain.CheckInRejected, domain.ReasonInvalidScan
+		return result
+	}
+
+	var user domain.User
+	checkIn := &domain.CheckIn{
+		ID:        scan.ID,
+		UserID:    scan.UserID,
+		ScannerID: scannerID,
+		Gate:      scan.Gate,
+		DeviceID:  deviceID,
+		Direction: scan.Direction,
+		Source:    domain.SourceOffline,
+		ScannedAt: scan.ScannedAt,
+	}
+
+	if !scan.Admitted {
+		// The device turned them away, so there is nothing to admit
+		checkIn.Result, checkIn.Reason = domain.CheckInRejected, scan.Reason
+		if checkIn.Reason == "" {
+			checkIn.Reason = domain.ReasonRejectedOffline
+		}
+	} else {
+		user, err = u.Admitter.AdmitToken(checkIn, scan.Token)
+		checkIn.Result, checkIn.Reason = scanOutcome(err)
+		if checkIn.Reason == domain.ReasonInternalError {
+			// Leave it unrecorded so the device's retry is reconciled afresh
+			result.Result, result.Reason = checkIn.Result, checkIn.Reason
+			return result
+		}
+		if err != nil {
+			checkIn.Result = domain.CheckInConflict
+			result.ConflictWith = u.lastAccepted(checkIn)
+		}
+	}
+
+	if checkIn.Result != domain.CheckInAccepted {
+		if err := u.CheckInRepo.Create(checkIn); err != nil {
+			log.Printf("Failed to record offline check-in %s: %v", checkIn.ID, err)
+			result.Result, result.Reason = domain.CheckInRejected, domain.ReasonInternalError
+			return result
+		}
+	}
+
+	publishCheckIn(u.Events, checkIn, user)
+
+	result.Result, result.Reason = checkIn.Result, checkIn.Reason
+	return result
+}
+
+// lastAccepted finds the accepted scan a conflicting offline scan clashes with
+func (u *ScannerUsecase) lastAccepted(checkIn *domain.CheckIn) *domain.CheckIn {
+	checkIns, err := u.CheckInRepo.List(domain.CheckInFilter{
+		UserID:    checkIn.UserID,
+		SessionID: checkIn.SessionID,
+		Result:    domain.CheckInAccepted,
+		Limit:     1,
+	})
+	if err != nil || len(checkIns) == 0 {
+		return nil
+	}
+	return &checkIns[0]
+}
+
+// scannerZone returns the zone of the gate the scanner is assigned to, or nil
+func (u *ScannerUsecase) scannerZone(scannerID string) (*domain.Zone, error) {
+	assignment, err := u.ZoneRepo.GetAssignment(scannerID)
+	if errors.Is(err, domain.ErrStaffNotAssigned) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	gate, err := u.ZoneRepo.GetGateById(assignment.GateID)
+	if err != nil {
+		return nil, err
+	}
+	zone, err := u.ZoneRepo.GetById(gate.ZoneID)
+	if errors.Is(err, domain.ErrZoneNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &zone, nil
+}
+
+// snapshotRulesVersion fingerprints what decides who a scanner may admit
+func snapshotRulesVersion(session domain.Session, zone *domain.Zone) (string, error) {
+	rules, err := json.Marshal(struct {
+		Session domain.Session `json:"session"`
+		Zone    *domain.Zone   `json:"zone"`
+	}{session, zone})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(rules)
+	return hex.EncodeToString(sum[:8]), nil
+}
+
+func photoHash(imageURL *string) string {
+	if imageURL == nil || *imageURL == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(*imageURL))
+	return hex.EncodeToString(sum[:])
+}
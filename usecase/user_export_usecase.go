@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/isd-sgcu/cutu2025-backend/domain"
+	"github.com/isd-sgcu/cutu2025-backend/utils"
+)
+
+// exportColumn is a column of the attendee spreadsheet. Name is what clients
+// select it by, matching the user's JSON field.
+type exportColumn struct {
+	name   string
+	header string
+	value  func(user domain.User) string
+}
+
+// exportColumns are every column that can be exported, in the order they appear
+var exportColumns = []exportColumn{
+	{"id", "ID", func(u domain.User) string { return u.ID }},
+	{"uid", "UID", func(u domain.User) string { return u.UID }},
+	{"name", "Name", func(u domain.User) string { return u.Name }},
+	{"email", "Email", func(u domain.User) string { return deref(u.Email) }},
+	{"phone", "Phone", func(u domain.User) string { return u.Phone }},
+	{"status", "Status", func(u domain.User) string { return string(u.Status) }},
+	{"registration", "Registration", func(u domain.User) string { return string(u.Registration) }},
+	{"role", "Role", func(u domain.User) string { return string(u.Role) }},
+	{"ticketType", "Ticket type", func(u domain.User) string { return u.TicketType }},
+	{"university", "University", func(u domain.User) string { return deref(u.University) }},
+	{"faculty", "Faculty", func(u domain.User) string { return deref(u.Faculty) }},
+	{"education", "Education", func(u domain.User) string { return string(deref(u.Education)) }},
+	{"graduatedYear", "Graduated year", func(u domain.User) string { return deref(u.GraduatedYear) }},
+	{"age", "Age", func(u domain.User) string { return deref(u.Age) }},
+	{"sizeJersey", "Jersey size", func(u domain.User) string { return string(deref(u.SizeJersey)) }},
+	{"foodLimitation", "Food limitation", func(u domain.User) string { return u.FoodLimitation }},
+	{"chronicDisease", "Chronic disease", func(u domain.User) string { return deref(u.ChronicDisease) }},
+	{"drugAllergy", "Drug allergy", func(u domain.User) string { return deref(u.DrugAllergy) }},
+	{"isAcroPhobia", "Acrophobia", func(u domain.User) string {
+		if u.IsAcroPhobia == nil {
+			return ""
+		}
+		return strconv.FormatBool(*u.IsAcroPhobia)
+	}},
+	{"invitationCode", "Invitation code", func(u domain.User) string { return deref(u.InvitationCode) }},
+	{"registeredAt", "Registered at", func(u domain.User) string { return u.RegisteredAt.Format(time.RFC3339) }},
+	{"lastEntered", "Last entered", func(u domain.User) string {
+		if u.LastEntered == nil {
+			return ""
+		}
+		return u.LastEntered.Format(time.RFC3339)
+	}},
+}
+
+func deref[T any](v *T) T {
+	var zero T
+	if v == nil {
+		return zero
+	}
+	return *v
+}
+
+// Export checks the query and returns a function that writes the matching
+// users to w as a spreadsheet. Users are read and written one at a time, so
+// exporting every user does not hold them all in memory. CSV rows reach w as
+// they are read; an XLSX workbook is only written once every row has been
+// read (see utils.NewXLSXWriter).
+func (u *UserUsecase) Export(query domain.UserExportQuery) (func(w io.Writer) error, error) {
+	columns, err := selectExportColumns(query.Columns)
+	if err != nil {
+		return nil, err
+	}
+
+	newWriter := utils.NewCSVWriter
+	if query.Format == domain.ExportXLSX {
+		newWriter = utils.NewXLSXWriter
+	}
+
+	return func(w io.Writer) error {
+		table, err := newWriter(w)
+		if err != nil {
+			return err
+		}
+
+		cells := make([]string, len(columns))
+		for i, column := range columns {
+			cells[i] = column.header
+		}
+		if err := table.WriteRow(cells); err != nil {
+			return err
+		}
+
+		err = u.Repo.Stream(query.UserFilter, func(user domain.User) error {
+			for i, column := range columns {
+				cells[i] = column.value(user)
+			}
+			return table.WriteRow(cells)
+		})
+		if err != nil {
+			return err
+		}
+		return table.Close()
+	}, nil
+}
+
+// selectExportColumns looks up the comma-separated column names, returning
+// every column if names is blank
+func selectExportColumns(names string) ([]exportColumn, error) {
+	if names == "" {
+		return exportColumns, nil
+	}
+
+	var columns []exportColumn
+	for _, name := range strings.Split(names, ",") {
+		column, ok := findExportColumn(strings.TrimSpace(name))
+		if !ok {
+			verr := &domain.ValidationError{}
+			verr.Add("columns", "oneof", "must be a comma-separated list of "+exportColumnNames())
+			return nil, verr
+		}
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
+
+func findExportColumn(name string) (exportColumn, bool) {
+	for _, column := range exportColumns {
+		if column.name == name {
+			return column, true
+		}
+	}
+	return exportColumn{}, false
+}
+
+func exportColumnNames() string {
+	names := make([]string, len(exportColumns))
+	for i, column := range exportColumns {
+		names[i] = column.name
+	}
+	return strings.Join(names, ", ")
+}
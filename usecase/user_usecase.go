@@ -2,16 +2,33 @@ package usecase
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"image"
+	"slices"
+	"strconv"
 	"time"
 
 	"github.com/isd-sgcu/cutu2025-backend/domain"
 	"github.com/isd-sgcu/cutu2025-backend/utils"
+	qrcode "github.com/skip2/go-qrcode"
 )
 
+// maxUIDAttempts bounds how often Register draws a new UID after a collision
+const maxUIDAttempts = 5
+
 type UserUsecase struct {
-	Repo    UserRepositoryInterface
-	Storage StorageRepositoryInterface
+	Repo            UserRepositoryInterface
+	Storage         StorageRepositoryInterface
+	IDTokenVerifier IDTokenVerifierInterface
+	TokenIssuer     TokenIssuerInterface
+	QRSigner        QRTokenSignerInterface
+	RoleGrantRepo   RoleGrantRepositoryInterface
+	InvitationRepo  InvitationRepositoryInterface
+	QRLogo          image.Image                          // Optional centre logo for rendered QR codes
+	RoleCache       *utils.TTLCache[string, domain.Role] // Optional; nil disables caching
 }
 
 type UserRepositoryInterface interface {
@@ -19,9 +36,12 @@ type UserRepositoryInterface interface {
 	GetAll() ([]domain.User, error)
 	GetById(id string) (domain.User, error)
 	GetByPhone(phone string) (domain.User, error)
-	GetByName(name string) ([]domain.User, error)
-	IsUIDExists(uid string) (bool, error)
-	Update(id string, user *domain.User) error
+	GetByUID(uid string) (domain.User, error)
+	List(query domain.UserListQuery) ([]domain.User, int64, error)
+	Stream(filter domain.UserFilter, fn func(user domain.User) error) error
+	GetUpdatedSince(since time.Time) ([]domain.User, error)
+	GetDeletedSince(since time.Time) ([]string, error)
+	Update(id string, user *domain.User, fields ...string) error
 	Delete(id string) error
 }
 
@@ -32,51 +52,121 @@ type StorageRepositoryInterface interface {
 	GetFileURL(bucketName, objectKey string) string
 }
 
-func NewUserUsecase(repo UserRepositoryInterface, storage StorageRepositoryInterface) *UserUsecase {
-	return &UserUsecase{Repo: repo, Storage: storage}
+type IDTokenVerifierInterface interface {
+	Verify(idToken string) (string, error)
 }
 
-func (u *UserUsecase) assignRole(user *domain.User) {
-	staffPhones := []string{"06", "08", "09"}
-	adminPhones := []string{"0919946459"}
-	user.Role = domain.Member
+type TokenIssuerInterface interface {
+	IssueTokens(userID string) (domain.TokenResponse, error)
+	RevokeAll(userID string) error
+}
 
-	if user.Phone != "" {
-		for _, phone := range staffPhones {
-			if user.Phone == phone {
-				user.Role = domain.Staff
-				break
-			}
-		}
+type QRTokenSignerInterface interface {
+	Sign(userID string, seq int) (string, utils.QRTokenClaims, error)
+	Verify(token string) (utils.QRTokenClaims, error)
+	VerifyAt(token string, at time.Time) (utils.QRTokenClaims, error)
+}
 
-		for _, phone := range adminPhones {
-			if user.Phone == phone {
-				user.Role = domain.Admin
-				break
-			}
-		}
+func NewUserUsecase(
+	repo UserRepositoryInterface,
+	storage StorageRepositoryInterface,
+	idTokenVerifier IDTokenVerifierInterface,
+	tokenIssuer TokenIssuerInterface,
+	qrSigner QRTokenSignerInterface,
+	roleGrantRepo RoleGrantRepositoryInterface,
+	invitationRepo InvitationRepositoryInterface,
+) *UserUsecase {
+	return &UserUsecase{
+		Repo:            repo,
+		Storage:         storage,
+		IDTokenVerifier: idTokenVerifier,
+		TokenIssuer:     tokenIssuer,
+		QRSigner:        qrSigner,
+		RoleGrantRepo:   roleGrantRepo,
+		InvitationRepo:  invitationRepo,
+	}
+}
+
+// assignRole gives a new user the highest role granted to their LIFF ID
+func (u *UserUsecase) assignRole(user *domain.User) error {
+	grants, err := u.RoleGrantRepo.GetForUser(user.ID)
+	if err != nil {
+		return err
+	}
+	user.Role = grantedRole(grants)
+	return nil
+}
+
+// verifyIDToken resolves a LINE ID token to the LIFF user ID it was issued for
+func (u *UserUsecase) verifyIDToken(idToken string) (string, error) {
+	id, err := u.IDTokenVerifier.Verify(idToken)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", domain.ErrInvalidIDToken, err)
 	}
+	return id, nil
 }
 
-func isSameDay(t1, t2 time.Time) bool {
-	y1, m1, d1 := t1.Date()
-	y2, m2, d2 := t2.Date()
-	return y1 == y2 && m1 == m2 && d1 == d2
+// normalizePhone puts a phone number in the form it is stored in, so the same
+// number always matches
+func normalizePhone(phone string) (string, error) {
+	normalized, ok := utils.NormalizePhone(phone)
+	if !ok {
+		verr := &domain.ValidationError{}
+		verr.Add("phone", "thaiphone", "must be a Thai phone number, e.g. 0812345678 or +66812345678")
+		return "", verr
+	}
+	return normalized, nil
 }
 
-func (u *UserUsecase) Register(user *domain.User, fileBytes []byte) (domain.TokenResponse, error) {
-	u.assignRole(user)
+// checkPhoneAvailable fails with domain.ErrPhoneTaken if a user other than id has the phone number
+func (u *UserUsecase) checkPhoneAvailable(phone, id string) error {
+	existing, err := u.Repo.GetByPhone(phone)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if existing.ID != id {
+		return domain.ErrPhoneTaken
+	}
+	return nil
+}
+
+func (u *UserUsecase) Register(idToken string, user *domain.User, fileBytes []byte) (domain.TokenResponse, error) {
+	var err error
+	if user.Phone, err = normalizePhone(user.Phone); err != nil {
+		return domain.TokenResponse{}, err
+	}
+
+	id, err := u.verifyIDToken(idToken)
+	if err != nil {
+		return domain.TokenResponse{}, err
+	}
+	user.ID = id
+
+	if err := u.checkPhoneAvailable(user.Phone, user.ID); err != nil {
+		return domain.TokenResponse{}, err
+	}
 
-	// Generate unique UID
-	for {
-		user.UID = utils.GenerateUID()
-		uidExists, err := u.Repo.IsUIDExists(user.UID)
+	if err := u.assignRole(user); err != nil {
+		return domain.TokenResponse{}, fmt.Errorf("error assigning role: %w", err)
+	}
+
+	// Reject a bad invitation code before uploading anything; it is checked
+	// again when it is consumed
+	code := normalizeInvitationCode(user.InvitationCode)
+	if code != "" {
+		invitation, err := u.InvitationRepo.GetByCode(code)
 		if err != nil {
-			return domain.TokenResponse{}, fmt.Errorf("error checking UID uniqueness: %w", err)
+			return domain.TokenResponse{}, invitationCodeError(err)
 		}
-		if !uidExists {
-			break
+		preview := *user
+		preview.RegisteredAt = time.Now()
+		if err := redeemInvitation(invitation, &preview); err != nil {
+			return domain.TokenResponse{}, invitationCodeError(err)
 		}
+		user.InvitationCode = &code
 	}
 
 	// Only upload image if fileBytes is not empty
@@ -95,34 +185,42 @@ func (u *UserUsecase) Register(user *domain.User, fileBytes []byte) (domain.Toke
 
 	user.RegisteredAt = time.Now()
 
-	// Create user in database
-	if err := u.Repo.Create(user); err != nil {
-		return domain.TokenResponse{}, fmt.Errorf("error saving user: %w", err)
+	// Create user in database, drawing a new UID if the unique constraint rejects it
+	for attempt := 1; ; attempt++ {
+		if user.UID, err = utils.GenerateUID(); err != nil {
+			return domain.TokenResponse{}, fmt.Errorf("error generating UID: %w", err)
+		}
+		if code != "" {
+			err = u.InvitationRepo.Redeem(code, user, user.RegisteredAt, func(invitation domain.Invitation) error {
+				return redeemInvitation(invitation, user)
+			})
+		} else {
+			err = u.Repo.Create(user)
+		}
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, domain.ErrUIDTaken) || attempt == maxUIDAttempts {
+			return domain.TokenResponse{}, fmt.Errorf("error saving user: %w", invitationCodeError(err))
+		}
 	}
 
-	// Generate JWT token
-	jwtSecret := utils.GetEnv("SECRET_JWT_KEY", "")
-	accessToken, err := utils.GenerateTokens(user.ID, jwtSecret)
+	// Generate JWT tokens
+	tokenResponse, err := u.TokenIssuer.IssueTokens(user.ID)
 	if err != nil {
 		return domain.TokenResponse{}, fmt.Errorf("error generating tokens: %w", err)
 	}
 
-	return domain.TokenResponse{
-		UserID:      user.ID,
-		AccessToken: accessToken,
-	}, nil
+	return tokenResponse, nil
 }
 
-func (u *UserUsecase) GetAll(filter string) ([]domain.User, error) {
-	if filter != "" {
-		users, err := u.Repo.GetByName(filter)
-		if err != nil {
-			return nil, err
-		}
-		return users, nil
+// List returns a page of the users matching the query
+func (u *UserUsecase) List(query domain.UserListQuery) (domain.UserPage, error) {
+	users, total, err := u.Repo.List(query)
+	if err != nil {
+		return domain.UserPage{}, err
 	}
-
-	return u.Repo.GetAll()
+	return domain.UserPage{Users: users, Total: total, Limit: query.Limit, Offset: query.Offset}, nil
 }
 
 func (u *UserUsecase) GetImageByUserId(id string) (string, error) {
@@ -138,51 +236,148 @@ func (u *UserUsecase) GetById(id string) (domain.User, error) {
 	return u.Repo.GetById(id)
 }
 
-func (u *UserUsecase) SignIn(id string) (domain.TokenResponse, error) {
+// GetRole returns the user's current role, served from RoleCache when enabled
+func (u *UserUsecase) GetRole(id string) (domain.Role, error) {
+	if u.RoleCache != nil {
+		if role, ok := u.RoleCache.Get(id); ok {
+			return role, nil
+		}
+	}
+
 	user, err := u.GetById(id)
+	if err != nil {
+		return "", err
+	}
+
+	if u.RoleCache != nil {
+		u.RoleCache.Set(id, user.Role)
+	}
+	return user.Role, nil
+}
+
+func (u *UserUsecase) invalidateRole(id string) {
+	if u.RoleCache != nil {
+		u.RoleCache.Delete(id)
+	}
+}
+
+func (u *UserUsecase) SignIn(idToken string) (domain.TokenResponse, error) {
+	id, err := u.verifyIDToken(idToken)
 	if err != nil {
 		return domain.TokenResponse{}, err
 	}
 
-	jwtSecret := utils.GetEnv("SECRET_JWT_KEY", "")
-	accessToken, err := utils.GenerateTokens(user.ID, jwtSecret)
+	user, err := u.GetById(id)
 	if err != nil {
 		return domain.TokenResponse{}, err
 	}
 
-	return domain.TokenResponse{
-		UserID:      user.ID,
-		AccessToken: accessToken,
-	}, nil
+	return u.TokenIssuer.IssueTokens(user.ID)
 }
 
-func (u *UserUsecase) Update(id string, updatedUser *domain.User) error {
-	_, err := u.GetById(id)
+// UpdateProfile applies a user's edits to their own profile
+func (u *UserUsecase) UpdateProfile(id string, req domain.UpdateProfileRequest) error {
+	user, err := u.GetById(id)
 	if err != nil {
 		return err
 	}
-
-	return u.Repo.Update(id, updatedUser)
+	changes := &userChanges{user: &user}
+	changes.applyProfile(req)
+	return u.saveChanges(changes)
 }
 
-func (u *UserUsecase) ScanQR(id string) (domain.User, error) {
+// AdminUpdate applies an admin's edits to a user. Roles are changed with
+// UpdateRole instead.
+func (u *UserUsecase) AdminUpdate(id string, req domain.AdminUpdateUserRequest) error {
 	user, err := u.GetById(id)
 	if err != nil {
-		return domain.User{}, err
+		return err
 	}
+	changes := &userChanges{user: &user}
+	changes.applyProfile(req.UpdateProfileRequest)
+	setField(changes, "Status", &user.Status, req.Status)
+	setField(changes, "TicketType", &user.TicketType, req.TicketType)
+	return u.saveChanges(changes)
+}
 
-	now := time.Now()
-	if user.LastEntered != nil && isSameDay(*user.LastEntered, now) {
-		return user, domain.ErrUserAlreadyEntered
+func (u *UserUsecase) saveChanges(changes *userChanges) error {
+	user := changes.user
+	if changes.has("Phone") {
+		var err error
+		if user.Phone, err = normalizePhone(user.Phone); err != nil {
+			return err
+		}
+		if err := u.checkPhoneAvailable(user.Phone, user.ID); err != nil {
+			return err
+		}
+	}
+	if len(changes.fields) == 0 {
+		return nil
+	}
+	// Check the rules registration enforces against the user as it will be
+	// saved, so e.g. clearing an alumnus's graduation year is refused
+	if changes.has("Status") || changes.has("GraduatedYear") || changes.has("Faculty") {
+		if err := user.CheckProfile(); err != nil {
+			return err
+		}
 	}
 
-	user.LastEntered = &now
-	err = u.Update(id, &user)
-	if err != nil {
-		return domain.User{}, err
+	defer u.invalidateRole(user.ID)
+	return u.Repo.Update(user.ID, user, changes.fields...)
+}
+
+// userChanges records which fields of a user an update request set
+type userChanges struct {
+	user   *domain.User
+	fields []string
+}
+
+func (c *userChanges) has(field string) bool {
+	return slices.Contains(c.fields, field)
+}
+
+func (c *userChanges) applyProfile(req domain.UpdateProfileRequest) {
+	user := c.user
+	setField(c, "Name", &user.Name, req.Name)
+	setOptional(c, "Email", &user.Email, req.Email)
+	setField(c, "Phone", &user.Phone, req.Phone)
+	setOptional(c, "University", &user.University, req.University)
+	setOptional(c, "SizeJersey", &user.SizeJersey, req.SizeJersey)
+	setField(c, "FoodLimitation", &user.FoodLimitation, req.FoodLimitation)
+	setOptional(c, "Age", &user.Age, req.Age)
+	setOptional(c, "ChronicDisease", &user.ChronicDisease, req.ChronicDisease)
+	setOptional(c, "DrugAllergy", &user.DrugAllergy, req.DrugAllergy)
+	setOptional(c, "GraduatedYear", &user.GraduatedYear, req.GraduatedYear)
+	setOptional(c, "Faculty", &user.Faculty, req.Faculty)
+	setOptional(c, "Education", &user.Education, req.Education)
+	if req.IsAcroPhobia != nil {
+		user.IsAcroPhobia = req.IsAcroPhobia
+		c.fields = append(c.fields, "IsAcroPhobia")
 	}
+}
 
-	return user, nil
+// setField sets a required field if the request gives it
+func setField[T any](c *userChanges, field string, dst *T, value *T) {
+	if value == nil {
+		return
+	}
+	*dst = *value
+	c.fields = append(c.fields, field)
+}
+
+// setOptional sets an optional field if the request gives it, clearing it if
+// the request gives ""
+func setOptional[T ~string](c *userChanges, field string, dst **T, value *T) {
+	if value == nil {
+		return
+	}
+	if *value == "" {
+		*dst = nil
+	} else {
+		v := *value
+		*dst = &v
+	}
+	c.fields = append(c.fields, field)
 }
 
 func (u *UserUsecase) UpdateRole(id string, role domain.Role) error {
@@ -190,22 +385,118 @@ func (u *UserUsecase) UpdateRole(id string, role domain.Role) error {
 	if err != nil {
 		return err
 	}
+	if user.Role == role {
+		return nil
+	}
 	user.Role = role
-	return u.Update(id, &user)
+	defer u.invalidateRole(id)
+	if err := u.Repo.Update(id, &user, "Role"); err != nil {
+		return err
+	}
+
+	// Force a fresh sign-in so existing sessions don't outlive the old role
+	return u.TokenIssuer.RevokeAll(id)
 }
 
-func (u *UserUsecase) GetQRURL(id string) (string, error) {
+// GetQRCode issues a short-lived signed QR token for the user; the attendee app
+// should fetch a new one before it expires
+func (u *UserUsecase) GetQRCode(id string) (domain.QrResponse, error) {
 	user, err := u.GetById(id)
 	if err != nil {
-		return "", err
+		return domain.QrResponse{}, err
+	}
+
+	return u.qrCode(user)
+}
+
+func (u *UserUsecase) qrCode(user domain.User) (domain.QrResponse, error) {
+	token, claims, err := u.QRSigner.Sign(user.ID, user.QrSeq)
+	if err != nil {
+		return domain.QrResponse{}, fmt.Errorf("error signing QR token: %w", err)
 	}
 
 	baseURL := utils.GetEnv("PRODUCTION_BASE_URL", "http://localhost:4000")
 
-	return fmt.Sprintf("%s/api/users/qr/%s", baseURL, user.ID), nil
+	return domain.QrResponse{
+		UserID:       user.ID,
+		QrURL:        fmt.Sprintf("%s/api/users/qr/%s", baseURL, token),
+		Token:        token,
+		ExpiresAt:    claims.ExpiresAt,
+		RefreshAfter: qrRefreshAfter(claims),
+	}, nil
+}
+
+// GetQRImage renders the user's current QR code. fresh is given the ETag of the
+// image that would be rendered; if it reports that the client already has that
+// image, the image is not rendered and NotModified is set instead.
+func (u *UserUsecase) GetQRImage(id string, opts domain.QrImageOptions, fresh func(etag string) bool) (domain.QrImage, error) {
+	if opts.Size < 64 || opts.Size > 2048 {
+		return domain.QrImage{}, domain.ErrInvalidQRImageOptions
+	}
+	level, err := utils.ParseQRRecoveryLevel(opts.Level)
+	if err != nil {
+		return domain.QrImage{}, domain.ErrInvalidQRImageOptions
+	}
+
+	logo := u.QRLogo
+	if !opts.Logo {
+		logo = nil
+	}
+	if logo != nil && level < qrcode.Highest {
+		// The logo hides modules, so use the strongest error correction
+		level = qrcode.Highest
+	}
+
+	user, err := u.GetById(id)
+	if err != nil {
+		return domain.QrImage{}, err
+	}
+
+	qr, err := u.qrCode(user)
+	if err != nil {
+		return domain.QrImage{}, err
+	}
+
+	sum := sha256.Sum256([]byte(qr.QrURL + "|" + string(opts.Format) + "|" + strconv.Itoa(opts.Size) + "|" +
+		strconv.Itoa(int(level)) + "|" + strconv.FormatBool(logo != nil)))
+	img := domain.QrImage{
+		ETag:         `"` + hex.EncodeToString(sum[:16]) + `"`,
+		RefreshAfter: qr.RefreshAfter,
+	}
+	if fresh(img.ETag) {
+		img.NotModified = true
+		return img, nil
+	}
+
+	switch opts.Format {
+	case domain.QrImagePNG:
+		img.ContentType = "image/png"
+		img.Data, err = utils.RenderQRPNG(qr.QrURL, level, opts.Size, logo)
+	case domain.QrImageSVG:
+		img.ContentType = "image/svg+xml"
+		img.Data, err = utils.RenderQRSVG(qr.QrURL, level, opts.Size, logo)
+	default:
+		return domain.QrImage{}, domain.ErrInvalidQRImageOptions
+	}
+	if err != nil {
+		return domain.QrImage{}, fmt.Errorf("error rendering QR code: %w", err)
+	}
+
+	return img, nil
+}
+
+// qrRefreshAfter returns the seconds until the signer starts issuing the next code
+func qrRefreshAfter(claims utils.QRTokenClaims) int {
+	refreshAt := claims.IssuedAt.Add(claims.ExpiresAt.Sub(claims.IssuedAt) / 2)
+	seconds := int(time.Until(refreshAt).Seconds()) + 1
+	if seconds < 1 {
+		return 1
+	}
+	return seconds
 }
 
 func (u *UserUsecase) Delete(id string) error {
+	defer u.invalidateRole(id)
 	return u.Repo.Delete(id)
 }
 
@@ -221,6 +512,10 @@ func (u *UserUsecase) GetCardID(id string) (string, error) {
 }
 
 func (u *UserUsecase) AddStaff(phone string) error {
+	phone, err := normalizePhone(phone)
+	if err != nil {
+		return err
+	}
 	user, err := u.Repo.GetByPhone(phone)
 	if err != nil {
 		return err
@@ -229,7 +524,5 @@ func (u *UserUsecase) AddStaff(phone string) error {
 	if user.Role == domain.Staff {
 		return domain.ErrUserAlreadyStaff
 	}
-
-	user.Role = domain.Staff
-	return u.Update(user.ID, &user)
+	return u.UpdateRole(user.ID, domain.Staff)
 }
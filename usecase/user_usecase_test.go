@@ -0,0 +1,89 @@
+package usecase_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/isd-sgcu/cutu2025-backend/domain"
+	"github.com/isd-sgcu/cutu2025-backend/usecase"
+	"github.com/isd-sgcu/cutu2025-backend/utils"
+)
+
+// fakeRegisterRepo rejects the first taken UIDs it is given as already in use
+type fakeRegisterRepo struct {
+	usecase.UserRepositoryInterface
+	taken   int
+	created []string // UIDs in the order they were tried
+}
+
+func (r *fakeRegisterRepo) GetByPhone(string) (domain.User, error) {
+	return domain.User{}, domain.ErrUserNotFound
+}
+
+func (r *fakeRegisterRepo) Create(user *domain.User) error {
+	r.created = append(r.created, user.UID)
+	if len(r.created) <= r.taken {
+		return domain.ErrUIDTaken
+	}
+	return nil
+}
+
+type fakeIDTokenVerifier struct{}
+
+func (fakeIDTokenVerifier) Verify(string) (string, error) {
+	return testUserID, nil
+}
+
+type fakeRoleGrantRepo struct {
+	usecase.RoleGrantRepositoryInterface
+}
+
+func (fakeRoleGrantRepo) GetForUser(string) ([]domain.RoleGrant, error) {
+	return nil, nil
+}
+
+type fakeTokenIssuer struct {
+	usecase.TokenIssuerInterface
+}
+
+func (fakeTokenIssuer) IssueTokens(userID string) (domain.TokenResponse, error) {
+	return domain.TokenResponse{UserID: userID}, nil
+}
+
+func TestUserUsecaseRegisterRetriesTakenUID(t *testing.T) {
+	tests := []struct {
+		name      string
+		taken     int
+		wantTries int
+		wantErr   error
+	}{
+		{"free", 0, 1, nil},
+		{"taken then free", 2, 3, nil},
+		{"free on the last attempt", 4, 5, nil},
+		{"taken every time", 100, 5, domain.ErrUIDTaken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeRegisterRepo{taken: tt.taken}
+			u := usecase.NewUserUsecase(repo, nil, fakeIDTokenVerifier{}, fakeTokenIssuer{}, nil, fakeRoleGrantRepo{}, nil)
+
+			user := &domain.User{Name: "Somchai", Phone: "0812345678", Status: domain.StatusGeneralPublic}
+			_, err := u.Register("id-token", user, nil)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Register() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(repo.created) != tt.wantTries {
+				t.Fatalf("Register() tried %d UIDs, want %d", len(repo.created), tt.wantTries)
+			}
+
+			seen := map[string]bool{}
+			for _, uid := range repo.created {
+				if !utils.ValidateUID(uid) || seen[uid] {
+					t.Fatalf("Register() tried UIDs %v, want a new valid UID each time", repo.created)
+				}
+				seen[uid] = true
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/isd-sgcu/cutu2025-backend/domain"
+)
+
+type ZoneUsecase struct {
+	Repo     ZoneRepositoryInterface
+	UserRepo UserRepositoryInterface
+}
+
+type ZoneRepositoryInterface interface {
+	Create(zone *domain.Zone) error
+	GetAll() ([]domain.Zone, error)
+	GetById(id string) (domain.Zone, error)
+	Update(zone *domain.Zone) error
+	Delete(id string) error
+	CreateGate(gate *domain.Gate) error
+	GetAllGates() ([]domain.Gate, error)
+	GetGateById(id string) (domain.Gate, error)
+	DeleteGate(id string) error
+	AssignStaff(assignment *domain.GateAssignment) error
+	UnassignStaff(gateID, staffID string) error
+	GetAssignment(staffID string) (domain.GateAssignment, error)
+	GetGateStaff(gateID string) ([]domain.GateAssignment, error)
+	GetOccupancy() (domain.Occupancy, error)
+}
+
+func NewZoneUsecase(repo ZoneRepositoryInterface, userRepo UserRepositoryInterface) *ZoneUsecase {
+	return &ZoneUsecase{Repo: repo, UserRepo: userRepo}
+}
+
+// validateZone checks capacity and that allow lists only hold known values
+func validateZone(zone *domain.Zone) error {
+	if zone.Name == "" || zone.Capacity < 0 {
+		return domain.ErrInvalidZone
+	}
+	for _, status := range zone.AllowedStatuses {
+		if !status.IsValid() {
+			return domain.ErrInvalidZone
+		}
+	}
+	for _, role := range zone.AllowedRoles {
+		if !role.IsValid() {
+			return domain.ErrInvalidZone
+		}
+	}
+	return nil
+}
+
+func (u *ZoneUsecase) Create(zone *domain.Zone) error {
+	if err := validateZone(zone); err != nil {
+		return err
+	}
+	zone.ID = uuid.NewString()
+	return u.Repo.Create(zone)
+}
+
+func (u *ZoneUsecase) GetAll() ([]domain.Zone, error) {
+	return u.Repo.GetAll()
+}
+
+// Update applies the fields present in req to the zone
+func (u *ZoneUsecase) Update(id string, req domain.UpdateZoneRequest) error {
+	zone, err := u.Repo.GetById(id)
+	if err != nil {
+		return err
+	}
+
+	if req.Name != nil {
+		zone.Name = *req.Name
+	}
+	if req.Capacity != nil {
+		zone.Capacity = *req.Capacity
+	}
+	if req.AllowedStatuses != nil {
+		zone.AllowedStatuses = *req.AllowedStatuses
+	}
+	if req.AllowedRoles != nil {
+		zone.AllowedRoles = *req.AllowedRoles
+	}
+	if req.AllowedTicketTypes != nil {
+		zone.AllowedTicketTypes = *req.AllowedTicketTypes
+	}
+
+	if err := validateZone(&zone); err != nil {
+		return err
+	}
+	return u.Repo.Update(&zone)
+}
+
+// Delete removes a zone. Zones that gates still admit into cannot be deleted;
+// move or delete the gates first.
+func (u *ZoneUsecase) Delete(id string) error {
+	return u.Repo.Delete(id)
+}
+
+func (u *ZoneUsecase) CreateGate(gate *domain.Gate) error {
+	if gate.ID == "" {
+		return domain.ErrInvalidGate
+	}
+	if _, err := u.Repo.GetById(gate.ZoneID); err != nil {
+		return err
+	}
+	return u.Repo.CreateGate(gate)
+}
+
+func (u *ZoneUsecase) GetAllGates() ([]domain.Gate, error) {
+	return u.Repo.GetAllGates()
+}
+
+func (u *ZoneUsecase) DeleteGate(id string) error {
+	return u.Repo.DeleteGate(id)
+}
+
+func (u *ZoneUsecase) GetOccupancy() (domain.Occupancy, error) {
+	return u.Repo.GetOccupancy()
+}
+
+// AssignStaff places a staff member at a gate. A staff member works one gate at
+// a time, so this replaces any earlier assignment.
+func (u *ZoneUsecase) AssignStaff(gateID, staffID string) (domain.GateAssignment, error) {
+	if _, err := u.Repo.GetGateById(gateID); err != nil {
+		return domain.GateAssignment{}, err
+	}
+
+	staff, err := u.UserRepo.GetById(staffID)
+	if err != nil {
+		return domain.GateAssignment{}, err
+	}
+	if staff.Role != domain.Staff && staff.Role != domain.Admin {
+		return domain.GateAssignment{}, domain.ErrInvalidStaff
+	}
+
+	assignment := domain.GateAssignment{
+		StaffID:    staffID,
+		GateID:     gateID,
+		AssignedAt: time.Now(),
+	}
+	if err := u.Repo.AssignStaff(&assignment); err != nil {
+		return domain.GateAssignment{}, err
+	}
+	return assignment, nil
+}
+
+func (u *ZoneUsecase) UnassignStaff(gateID, staffID string) error {
+	return u.Repo.UnassignStaff(gateID, staffID)
+}
+
+func (u *ZoneUsecase) GetGateStaff(gateID string) ([]domain.GateAssignment, error) {
+	if _, err := u.Repo.GetGateById(gateID); err != nil {
+		return nil, err
+	}
+	return u.Repo.GetGateStaff(gateID)
+}
@@ -0,0 +1,51 @@
+package utils
+
+import "sync"
+
+// brokerBuffer is how many messages a subscriber may fall behind before messages are dropped for it
+const brokerBuffer = 64
+
+// Broker is an in-process pub/sub that fans every published message out to all
+// current subscribers. A slow subscriber misses messages rather than blocking
+// publishers.
+type Broker[T any] struct {
+	mu          sync.RWMutex
+	subscribers map[chan T]struct{}
+}
+
+func NewBroker[T any]() *Broker[T] {
+	return &Broker[T]{subscribers: make(map[chan T]struct{})}
+}
+
+func (b *Broker[T]) Publish(message T) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- message:
+		default:
+		}
+	}
+	return nil
+}
+
+// Subscribe returns a channel of published messages and a function that must be
+// called to unsubscribe, after which the channel is closed
+func (b *Broker[T]) Subscribe() (<-chan T, func()) {
+	ch := make(chan T, brokerBuffer)
+
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscribers, ch)
+			close(ch)
+			b.mu.Unlock()
+		})
+	}
+}
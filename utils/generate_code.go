@@ -0,0 +1,20 @@
+package utils
+
+import (
+	"crypto/rand"
+	"math/big"
+)
+
+// GenerateCode generates a random code of the given length from the same
+// easy-to-read alphabet as UIDs
+func GenerateCode(length int) (string, error) {
+	code := make([]byte, length)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(uidAlphabet))))
+		if err != nil {
+			return "", err
+		}
+		code[i] = uidAlphabet[n.Int64()]
+	}
+	return string(code), nil
+}
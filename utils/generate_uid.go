@@ -1,27 +1,74 @@
 package utils
 
 import (
-	"fmt"
-	"math/rand"
-	"time"
+	"crypto/rand"
+	"math/big"
+	"strings"
 )
 
-// GenerateUID generates a UID in the format AB12345678
-func GenerateUID() string {
-	// Define letters and digits
-	letters := "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
-	digits := "0123456789"
+// UID characters leave out 0, 1, I and O, which are easily misread on screens and when read aloud
+const (
+	uidLetters  = "ABCDEFGHJKLMNPQRSTUVWXYZ"
+	uidDigits   = "23456789"
+	uidAlphabet = uidDigits + uidLetters // Check characters are drawn from both
+)
+
+// GenerateUID generates a UID in the format AB2345678C: two letters, seven
+// digits and a check character, all from crypto/rand
+func GenerateUID() (string, error) {
+	var b strings.Builder
+	for i := 0; i < 9; i++ {
+		charset := uidDigits
+		if i < 2 {
+			charset = uidLetters
+		}
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(charset))))
+		if err != nil {
+			return "", err
+		}
+		b.WriteByte(charset[n.Int64()])
+	}
 
-	// Seed the random number generator
-	rand.NewSource(time.Now().UnixNano())
+	payload := b.String()
+	return payload + string(uidCheckChar(payload)), nil
+}
 
-	// Generate two random letters
-	uid := fmt.Sprintf("%c%c", letters[rand.Intn(len(letters))], letters[rand.Intn(len(letters))])
+// NormalizeUID uppercases a UID typed by staff and drops separators
+func NormalizeUID(uid string) string {
+	uid = strings.ToUpper(strings.TrimSpace(uid))
+	return strings.NewReplacer(" ", "", "-", "").Replace(uid)
+}
 
-	// Generate eight random digits
-	for i := 0; i < 8; i++ {
-		uid += string(digits[rand.Intn(len(digits))])
+// ValidateUID reports whether a UID is well formed and its check character
+// matches, catching single-character typos and most swapped neighbours
+func ValidateUID(uid string) bool {
+	if len(uid) != 10 {
+		return false
+	}
+	for i := 0; i < 9; i++ {
+		charset := uidDigits
+		if i < 2 {
+			charset = uidLetters
+		}
+		if strings.IndexByte(charset, uid[i]) < 0 {
+			return false
+		}
 	}
+	return uidCheckChar(uid[:9]) == uid[9]
+}
 
-	return uid
+// uidCheckChar computes a Luhn mod N check character over uidAlphabet
+func uidCheckChar(payload string) byte {
+	n := len(uidAlphabet)
+	factor, sum := 2, 0
+	for i := len(payload) - 1; i >= 0; i-- {
+		addend := factor * strings.IndexByte(uidAlphabet, payload[i])
+		sum += addend/n + addend%n
+		if factor == 2 {
+			factor = 1
+		} else {
+			factor = 2
+		}
+	}
+	return uidAlphabet[(n-sum%n)%n]
 }
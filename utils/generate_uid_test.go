@@ -0,0 +1,101 @@
+package utils_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/isd-sgcu/cutu2025-backend/utils"
+)
+
+// uidCharsets are the characters each position of a UID may hold
+var uidCharsets = [10]string{
+	"ABCDEFGHJKLMNPQRSTUVWXYZ", "ABCDEFGHJKLMNPQRSTUVWXYZ",
+	"23456789", "23456789", "23456789", "23456789", "23456789", "23456789", "23456789",
+	"23456789ABCDEFGHJKLMNPQRSTUVWXYZ",
+}
+
+func TestGenerateUIDPassesCheck(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		uid, err := utils.GenerateUID()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !utils.ValidateUID(uid) {
+			t.Fatalf("ValidateUID(%q) = false for a generated UID", uid)
+		}
+	}
+}
+
+func TestValidateUIDRejectsSingleChange(t *testing.T) {
+	uid, err := utils.GenerateUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Every other character allowed at a position must be caught
+	for i := range uid {
+		for _, c := range uidCharsets[i] {
+			if byte(c) == uid[i] {
+				continue
+			}
+			typo := uid[:i] + string(c) + uid[i+1:]
+			if utils.ValidateUID(typo) {
+				t.Errorf("ValidateUID(%q) = true, changed position %d of %q", typo, i, uid)
+			}
+		}
+	}
+}
+
+func TestValidateUID(t *testing.T) {
+	uid, err := utils.GenerateUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		uid  string
+		want bool
+	}{
+		{"generated", uid, true},
+		{"too short", uid[:9], false},
+		{"too long", uid + "2", false},
+		{"lowercase", strings.ToLower(uid), false},
+		{"misread letter", "O" + uid[1:], false},
+		{"misread digit", uid[:2] + "1" + uid[3:], false},
+		{"empty", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := utils.ValidateUID(tt.uid); got != tt.want {
+				t.Fatalf("ValidateUID(%q) = %v, want %v", tt.uid, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeUID(t *testing.T) {
+	uid, err := utils.GenerateUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		typed string
+	}{
+		{"as shown", uid},
+		{"lowercase", strings.ToLower(uid)},
+		{"with spaces", " " + uid[:2] + " " + uid[2:6] + " " + uid[6:] + " "},
+		{"with dashes", uid[:2] + "-" + uid[2:6] + "-" + uid[6:]},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := utils.NormalizeUID(tt.typed); got != uid {
+				t.Fatalf("NormalizeUID(%q) = %q, want %q", tt.typed, got, uid)
+			}
+		})
+	}
+}
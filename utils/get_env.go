@@ -1,6 +1,10 @@
 package utils
 
-import "os"
+import (
+	"log"
+	"os"
+	"time"
+)
 
 func GetEnv(key, fallback string) string {
 	if value, exists := os.LookupEnv(key); exists {
@@ -8,3 +12,16 @@ func GetEnv(key, fallback string) string {
 	}
 	return fallback
 }
+
+func GetEnvDuration(key string, fallback time.Duration) time.Duration {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return fallback
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		log.Printf("Invalid duration %q for %s, using %v", value, key, fallback)
+		return fallback
+	}
+	return d
+}
@@ -1,50 +1,127 @@
 package utils
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
+	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
-// generateTokens creates an access and refresh token
-func GenerateTokens(userID string, jwtSecret string) (string, error) {
-	// Access Token
-	accessTokenClaims := jwt.MapClaims{
-		"userId": userID,
+// StreamTokenAudience marks stream tokens, so they cannot be used as access tokens
+const StreamTokenAudience = "dashboard-stream"
+
+// AccessTokenClaims are the claims carried by our access tokens
+type AccessTokenClaims struct {
+	UserID string `json:"userId"`
+	jwt.RegisteredClaims
+}
+
+// GenerateAccessToken creates a short-lived access token and returns it with its expiry time
+func GenerateAccessToken(userID string, jwtSecret string, ttl time.Duration) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(ttl)
+
+	accessTokenClaims := AccessTokenClaims{
+		UserID: userID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
 	}
 	accessToken := jwt.NewWithClaims(jwt.SigningMethodHS256, accessTokenClaims)
 	access, err := accessToken.SignedString([]byte(jwtSecret))
 	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return access, expiresAt, nil
+}
+
+// GenerateStreamToken creates a short-lived token for opening the dashboard
+// stream. Browsers' EventSource cannot send an Authorization header, so it is
+// passed in the query string instead and is only accepted there.
+func GenerateStreamToken(userID string, jwtSecret string, ttl time.Duration) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(ttl)
+
+	streamTokenClaims := AccessTokenClaims{
+		UserID: userID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Audience:  jwt.ClaimStrings{StreamTokenAudience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+	streamToken := jwt.NewWithClaims(jwt.SigningMethodHS256, streamTokenClaims)
+	stream, err := streamToken.SignedString([]byte(jwtSecret))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return stream, expiresAt, nil
+}
+
+// GenerateRefreshToken creates an opaque random refresh token
+func GenerateRefreshToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
 		return "", err
 	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
 
-	return access, nil
+// HashToken returns the SHA-256 hex digest of a token, so only hashes are stored server-side
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
 }
 
-// DecodeToken decodes the JWT token and returns the userID and any error encountered
-func DecodeToken(tokenString string, jwtSecret string) (string, error) {
-	// Parse and validate the token
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+// DecodeToken decodes the JWT token and returns its claims and any error encountered
+func DecodeToken(tokenString string, jwtSecret string) (*AccessTokenClaims, error) {
+	claims, err := decodeToken(tokenString, jwtSecret)
+	if err != nil {
+		return nil, err
+	}
+	// Stream tokens travel in URLs, so they must not grant anything else
+	if len(claims.Audience) > 0 {
+		return nil, errors.New("unexpected token audience")
+	}
+	return claims, nil
+}
+
+// DecodeStreamToken decodes a token created by GenerateStreamToken
+func DecodeStreamToken(tokenString string, jwtSecret string) (*AccessTokenClaims, error) {
+	return decodeToken(tokenString, jwtSecret, jwt.WithAudience(StreamTokenAudience))
+}
+
+func decodeToken(tokenString string, jwtSecret string, opts ...jwt.ParserOption) (*AccessTokenClaims, error) {
+	// Parse and validate the token; tokens without an expiry are rejected
+	claims := new(AccessTokenClaims)
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
 		// Check the signing method to ensure it's using the expected algorithm
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, errors.New("unexpected signing method")
 		}
 		return []byte(jwtSecret), nil
-	})
+	}, append(opts, jwt.WithExpirationRequired())...)
 
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	// Check if the token is valid and extract claims
-	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-		// Extract userID from the claims
-		userID, ok := claims["userId"].(string)
-		if !ok {
-			return "", errors.New("userId not found in token")
-		}
-		return userID, nil
+	if !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+	if claims.UserID == "" {
+		return nil, errors.New("userId not found in token")
 	}
 
-	return "", errors.New("invalid token")
+	return claims, nil
 }
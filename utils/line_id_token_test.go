@@ -0,0 +1,163 @@
+package utils_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/MicahParks/keyfunc/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/isd-sgcu/cutu2025-backend/utils"
+)
+
+const (
+	testChannelID = "1234567890"
+	testIssuer    = "https://access.line.me"
+	testKeyID     = "line-key"
+)
+
+// newFakeJWKS serves key as LINE's JWKS and returns a verifier that fetches it
+func newFakeJWKS(t *testing.T, key *ecdsa.PrivateKey) *utils.LineIDTokenVerifier {
+	t.Helper()
+
+	encode := func(n *big.Int) string {
+		return base64.RawURLEncoding.EncodeToString(n.FillBytes(make([]byte, 32)))
+	}
+	body, err := json.Marshal(map[string]any{
+		"keys": []map[string]string{{
+			"kty": "EC",
+			"crv": "P-256",
+			"alg": "ES256",
+			"use": "sig",
+			"kid": testKeyID,
+			"x":   encode(key.X),
+			"y":   encode(key.Y),
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body)
+	}))
+	t.Cleanup(server.Close)
+
+	jwks, err := keyfunc.Get(server.URL, keyfunc.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(jwks.EndBackground)
+
+	return utils.NewLineIDTokenVerifier(jwks.Keyfunc, testChannelID, testIssuer)
+}
+
+func newECKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func validClaims() jwt.MapClaims {
+	now := time.Now()
+	return jwt.MapClaims{
+		"iss": testIssuer,
+		"sub": "U1234567890abcdef",
+		"aud": testChannelID,
+		"iat": now.Unix(),
+		"exp": now.Add(time.Hour).Unix(),
+	}
+}
+
+func signES256(t *testing.T, key *ecdsa.PrivateKey, kid string, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func TestLineIDTokenVerifierVerify(t *testing.T) {
+	key := newECKey(t)
+	otherKey := newECKey(t)
+	verifier := newFakeJWKS(t, key)
+
+	withClaim := func(name string, value any) jwt.MapClaims {
+		claims := validClaims()
+		if value == nil {
+			delete(claims, name)
+		} else {
+			claims[name] = value
+		}
+		return claims
+	}
+
+	hs256 := jwt.NewWithClaims(jwt.SigningMethodHS256, validClaims())
+	hs256.Header["kid"] = testKeyID
+	hs256Token, err := hs256.SignedString([]byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	none := jwt.NewWithClaims(jwt.SigningMethodNone, validClaims())
+	none.Header["kid"] = testKeyID
+	noneToken, err := none.SignedString(jwt.UnsafeAllowNoneSignatureType)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantSub string
+		wantErr error
+	}{
+		{"valid token", signES256(t, key, testKeyID, validClaims()), "U1234567890abcdef", nil},
+		{"wrong audience", signES256(t, key, testKeyID, withClaim("aud", "another-channel")), "", jwt.ErrTokenInvalidAudience},
+		{"wrong issuer", signES256(t, key, testKeyID, withClaim("iss", "https://evil.example.com")), "", jwt.ErrTokenInvalidIssuer},
+		{"expired token", signES256(t, key, testKeyID, withClaim("exp", time.Now().Add(-time.Minute).Unix())), "", jwt.ErrTokenExpired},
+		{"missing exp", signES256(t, key, testKeyID, withClaim("exp", nil)), "", jwt.ErrTokenRequiredClaimMissing},
+		{"bad signature", signES256(t, otherKey, testKeyID, validClaims()), "", jwt.ErrTokenSignatureInvalid},
+		{"unknown kid", signES256(t, key, "rotated-key", validClaims()), "", keyfunc.ErrKIDNotFound},
+		{"disallowed alg HS256", hs256Token, "", jwt.ErrTokenSignatureInvalid},
+		{"disallowed alg none", noneToken, "", jwt.ErrTokenSignatureInvalid},
+		{"missing sub", signES256(t, key, testKeyID, withClaim("sub", nil)), "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sub, err := verifier.Verify(tt.token)
+			if tt.wantSub != "" {
+				if err != nil {
+					t.Fatalf("Verify() error = %v, want nil", err)
+				}
+				if sub != tt.wantSub {
+					t.Fatalf("Verify() = %q, want %q", sub, tt.wantSub)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatalf("Verify() = %q, want an error", sub)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Verify() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
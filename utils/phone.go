@@ -0,0 +1,39 @@
+package utils
+
+import "strings"
+
+// NormalizePhone converts a Thai phone number to its local form, e.g.
+// "+66 81-234-5678" to "0812345678", so the same number is always stored and
+// looked up the same way. It reports false if phone is not a Thai number.
+func NormalizePhone(phone string) (string, bool) {
+	digits := strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '.', '(', ')':
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(phone))
+
+	switch {
+	case strings.HasPrefix(digits, "+66"):
+		digits = "0" + strings.TrimPrefix(strings.TrimPrefix(digits, "+66"), "0")
+	case strings.HasPrefix(digits, "66") && (len(digits) == 10 || len(digits) == 11):
+		digits = "0" + digits[2:]
+	}
+
+	// Mobile numbers (06, 08, 09) have ten digits and landlines nine, both
+	// starting with 0
+	wantLen := 9
+	if len(digits) > 1 && strings.IndexByte("689", digits[1]) >= 0 {
+		wantLen = 10
+	}
+	if len(digits) != wantLen || digits[0] != '0' || digits[1] == '0' {
+		return "", false
+	}
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return "", false
+		}
+	}
+	return digits, true
+}
@@ -0,0 +1,45 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/isd-sgcu/cutu2025-backend/utils"
+)
+
+func TestNormalizePhone(t *testing.T) {
+	tests := []struct {
+		name   string
+		phone  string
+		want   string
+		wantOK bool
+	}{
+		{"local mobile", "0812345678", "0812345678", true},
+		{"+66 mobile", "+66812345678", "0812345678", true},
+		{"+66 with trunk zero", "+660812345678", "0812345678", true},
+		{"66 mobile", "66812345678", "0812345678", true},
+		{"66 landline", "6621234567", "021234567", true},
+		{"dashes", "081-234-5678", "0812345678", true},
+		{"spaces", " 081 234 5678 ", "0812345678", true},
+		{"+66 with dashes and spaces", "+66 81-234-5678", "0812345678", true},
+		{"dots and parentheses", "(02) 123.4567", "021234567", true},
+		{"landline", "021234567", "021234567", true},
+		{"landline with a mobile length", "0212345678", "", false},
+		{"mobile with a landline length", "081234567", "", false},
+		{"too short", "02123456", "", false},
+		{"too long", "08123456789", "", false},
+		{"no trunk zero", "812345678", "", false},
+		{"double zero", "0012345678", "", false},
+		{"letters", "08123456ab", "", false},
+		{"other country code", "+6512345678", "", false},
+		{"empty", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := utils.NormalizePhone(tt.phone)
+			if got != tt.want || ok != tt.wantOK {
+				t.Fatalf("NormalizePhone(%q) = %q, %v, want %q, %v", tt.phone, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+// ParseQRRecoveryLevel maps "low", "medium", "high" and "highest" to a QR error-correction level
+func ParseQRRecoveryLevel(level string) (qrcode.RecoveryLevel, error) {
+	switch level {
+	case "low":
+		return qrcode.Low, nil
+	case "", "medium":
+		return qrcode.Medium, nil
+	case "high":
+		return qrcode.High, nil
+	case "highest":
+		return qrcode.Highest, nil
+	}
+	return 0, fmt.Errorf("unknown QR recovery level %q", level)
+}
+
+// RenderQRPNG renders content as a size x size PNG, with logo drawn in the centre if not nil
+func RenderQRPNG(content string, level qrcode.RecoveryLevel, size int, logo image.Image) ([]byte, error) {
+	qr, err := qrcode.New(content, level)
+	if err != nil {
+		return nil, err
+	}
+
+	if logo == nil {
+		return qr.PNG(size)
+	}
+
+	code := qr.Image(size)
+	img := image.NewRGBA(code.Bounds())
+	draw.Draw(img, img.Bounds(), code, image.Point{}, draw.Src)
+
+	// Keep the logo to a fifth of the width so the error correction can recover the covered modules
+	logoSize := img.Bounds().Dx() / 5
+	offset := (img.Bounds().Dx() - logoSize) / 2
+	rect := image.Rect(offset, offset, offset+logoSize, offset+logoSize)
+	draw.Draw(img, rect.Inset(-logoSize/10), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(img, rect, scaleImage(logo, logoSize), image.Point{}, draw.Over)
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// RenderQRSVG renders content as an SVG of the given size, with logo embedded in the centre if not nil
+func RenderQRSVG(content string, level qrcode.RecoveryLevel, size int, logo image.Image) ([]byte, error) {
+	qr, err := qrcode.New(content, level)
+	if err != nil {
+		return nil, err
+	}
+
+	bitmap := qr.Bitmap()
+	modules := len(bitmap)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`, size, size, modules, modules)
+	fmt.Fprintf(&buf, `<rect width="%d" height="%d" fill="#fff"/><path fill="#000" d="`, modules, modules)
+	for y, row := range bitmap {
+		for x, dark := range row {
+			if dark {
+				fmt.Fprintf(&buf, "M%d %dh1v1h-1z", x, y)
+			}
+		}
+	}
+	buf.WriteString(`"/>`)
+
+	if logo != nil {
+		var logoPNG bytes.Buffer
+		if err := png.Encode(&logoPNG, logo); err != nil {
+			return nil, err
+		}
+		logoSize := float64(modules) / 5
+		offset := (float64(modules) - logoSize) / 2
+		pad := logoSize / 10
+		fmt.Fprintf(&buf, `<rect x="%.2f" y="%.2f" width="%.2f" height="%.2f" fill="#fff"/>`, offset-pad, offset-pad, logoSize+2*pad, logoSize+2*pad)
+		fmt.Fprintf(&buf, `<image x="%.2f" y="%.2f" width="%.2f" height="%.2f" href="data:image/png;base64,%s"/>`,
+			offset, offset, logoSize, logoSize, base64.StdEncoding.EncodeToString(logoPNG.Bytes()))
+	}
+
+	buf.WriteString(`</svg>`)
+	return buf.Bytes(), nil
+}
+
+// scaleImage resizes src to a size x size square using nearest-neighbour sampling
+func scaleImage(src image.Image, size int) image.Image {
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	b := src.Bounds()
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			dst.Set(x, y, src.At(b.Min.X+x*b.Dx()/size, b.Min.Y+y*b.Dy()/size))
+		}
+	}
+	return dst
+}
@@ -0,0 +1,166 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrQRTokenInvalid = errors.New("invalid QR token")
+	ErrQRTokenExpired = errors.New("QR token has expired")
+)
+
+// qrClockSkew tolerates scanner and server clocks being slightly apart
+const qrClockSkew = 5 * time.Second
+
+// QRKey is an HMAC key used to sign QR tokens, identified by a short key ID
+type QRKey struct {
+	ID     string
+	Secret []byte
+}
+
+// QRTokenClaims is the content of a QR token
+type QRTokenClaims struct {
+	UserID    string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+	Nonce     string
+}
+
+// QRTokenSigner issues and verifies QR tokens of the form <kid>.<payload>.<signature>.
+// The first key signs new tokens; every key is accepted for verification, so a
+// new key can be put in front while in-flight codes signed by the old one stay valid.
+type QRTokenSigner struct {
+	Keys []QRKey
+	TTL  time.Duration
+}
+
+func NewQRTokenSigner(keys []QRKey, ttl time.Duration) *QRTokenSigner {
+	return &QRTokenSigner{Keys: keys, TTL: ttl}
+}
+
+// ParseQRKeys parses keys in the form "kid1:secret1,kid2:secret2"
+func ParseQRKeys(raw string) ([]QRKey, error) {
+	var keys []QRKey
+	for _, pair := range strings.Split(raw, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		id, secret, ok := strings.Cut(pair, ":")
+		if !ok || id == "" || secret == "" || strings.Contains(id, ".") {
+			return nil, fmt.Errorf("malformed QR key %q", id)
+		}
+		keys = append(keys, QRKey{ID: id, Secret: []byte(secret)})
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no QR signing keys configured")
+	}
+	return keys, nil
+}
+
+// Sign issues a QR token for the user. Tokens are stable for half the TTL, so
+// repeated requests within that window return the same code (and the same image),
+// while the nonce still differs from one window to the next. seq is the number
+// of codes the user has used up; it changes the nonce as soon as a code is
+// accepted, so the attendee can fetch a fresh one straight away.
+func (s *QRTokenSigner) Sign(userID string, seq int) (string, QRTokenClaims, error) {
+	key := s.Keys[0]
+
+	window := s.TTL / 2
+	if window < time.Second {
+		window = time.Second
+	}
+	issuedAt := time.Now().Truncate(window)
+
+	nonce := sign(key.Secret, "nonce|"+userID+"|"+strconv.FormatInt(issuedAt.Unix(), 36)+"|"+strconv.Itoa(seq))
+	claims := QRTokenClaims{
+		UserID:    userID,
+		IssuedAt:  issuedAt,
+		ExpiresAt: issuedAt.Add(s.TTL),
+		Nonce:     nonce[:12],
+	}
+
+	// The validity window travels with the token so changing TTL doesn't affect codes already issued
+	payload := strings.Join([]string{
+		claims.UserID,
+		strconv.FormatInt(claims.IssuedAt.Unix(), 36),
+		strconv.FormatInt(int64(s.TTL/time.Second), 36),
+		claims.Nonce,
+	}, "|")
+
+	signed := key.ID + "." + base64.RawURLEncoding.EncodeToString([]byte(payload))
+	return signed + "." + sign(key.Secret, signed), claims, nil
+}
+
+// Verify checks the signature and freshness of a QR token and returns its claims
+func (s *QRTokenSigner) Verify(token string) (QRTokenClaims, error) {
+	return s.VerifyAt(token, time.Now())
+}
+
+// VerifyAt is Verify for a token scanned at the given time, e.g. by a device
+// that was offline and uploads its scans later
+func (s *QRTokenSigner) VerifyAt(token string, at time.Time) (QRTokenClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return QRTokenClaims{}, ErrQRTokenInvalid
+	}
+
+	var key *QRKey
+	for i := range s.Keys {
+		if s.Keys[i].ID == parts[0] {
+			key = &s.Keys[i]
+			break
+		}
+	}
+	if key == nil {
+		return QRTokenClaims{}, ErrQRTokenInvalid
+	}
+
+	expected := sign(key.Secret, parts[0]+"."+parts[1])
+	if !hmac.Equal([]byte(expected), []byte(parts[2])) {
+		return QRTokenClaims{}, ErrQRTokenInvalid
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return QRTokenClaims{}, ErrQRTokenInvalid
+	}
+	fields := strings.Split(string(payload), "|")
+	if len(fields) != 4 || fields[0] == "" || fields[3] == "" {
+		return QRTokenClaims{}, ErrQRTokenInvalid
+	}
+	iat, err := strconv.ParseInt(fields[1], 36, 64)
+	if err != nil {
+		return QRTokenClaims{}, ErrQRTokenInvalid
+	}
+	validity, err := strconv.ParseInt(fields[2], 36, 64)
+	if err != nil {
+		return QRTokenClaims{}, ErrQRTokenInvalid
+	}
+
+	claims := QRTokenClaims{
+		UserID:    fields[0],
+		IssuedAt:  time.Unix(iat, 0),
+		ExpiresAt: time.Unix(iat+validity, 0),
+		Nonce:     fields[3],
+	}
+
+	if at.Add(qrClockSkew).Before(claims.IssuedAt) || at.After(claims.ExpiresAt.Add(qrClockSkew)) {
+		return claims, ErrQRTokenExpired
+	}
+
+	return claims, nil
+}
+
+func sign(secret []byte, message string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(message))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,128 @@
+package utils_test
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/isd-sgcu/cutu2025-backend/utils"
+)
+
+const (
+	testQRUserID = "U1234567890abcdef"
+	testQRTTL    = time.Minute
+	// testQRClockSkew is how far apart scanner and server clocks may be
+	testQRClockSkew = 5 * time.Second
+)
+
+var (
+	oldQRKey = utils.QRKey{ID: "k1", Secret: []byte("old-secret")}
+	newQRKey = utils.QRKey{ID: "k2", Secret: []byte("new-secret")}
+)
+
+func signQR(t *testing.T, signer *utils.QRTokenSigner, seq int) (string, utils.QRTokenClaims) {
+	t.Helper()
+	token, claims, err := signer.Sign(testQRUserID, seq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token, claims
+}
+
+// withPayload replaces the payload of token, keeping its key ID and signature
+func withPayload(token, payload string) string {
+	parts := strings.Split(token, ".")
+	return parts[0] + "." + base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." + parts[2]
+}
+
+func TestQRTokenSignerVerify(t *testing.T) {
+	signer := utils.NewQRTokenSigner([]utils.QRKey{oldQRKey}, testQRTTL)
+	token, claims := signQR(t, signer, 0)
+
+	forged := utils.NewQRTokenSigner([]utils.QRKey{{ID: oldQRKey.ID, Secret: []byte("guessed")}}, testQRTTL)
+	forgedToken, _ := signQR(t, forged, 0)
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.Split(token, ".")[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherUser := strings.Replace(string(payload), testQRUserID, "U0000000000000000", 1)
+
+	tests := []struct {
+		name    string
+		token   string
+		at      time.Time
+		wantErr error
+	}{
+		{"round trip", token, claims.IssuedAt, nil},
+		{"bad signature", token[:len(token)-2] + "AA", claims.IssuedAt, utils.ErrQRTokenInvalid},
+		{"signed with another secret", forgedToken, claims.IssuedAt, utils.ErrQRTokenInvalid},
+		{"payload changed", withPayload(token, otherUser), claims.IssuedAt, utils.ErrQRTokenInvalid},
+		{"unknown key ID", "k9" + strings.TrimPrefix(token, oldQRKey.ID), claims.IssuedAt, utils.ErrQRTokenInvalid},
+		{"malformed", "not-a-token", claims.IssuedAt, utils.ErrQRTokenInvalid},
+		{"at expiry", token, claims.ExpiresAt, nil},
+		{"last moment of clock skew", token, claims.ExpiresAt.Add(testQRClockSkew), nil},
+		{"expired", token, claims.ExpiresAt.Add(testQRClockSkew + time.Nanosecond), utils.ErrQRTokenExpired},
+		{"scanner clock behind", token, claims.IssuedAt.Add(-testQRClockSkew), nil},
+		{"not yet issued", token, claims.IssuedAt.Add(-testQRClockSkew - time.Second), utils.ErrQRTokenExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := signer.VerifyAt(tt.token, tt.at)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("VerifyAt() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("VerifyAt() error = %v, want nil", err)
+			}
+			if got.UserID != claims.UserID || got.Nonce != claims.Nonce ||
+				!got.IssuedAt.Equal(claims.IssuedAt) || !got.ExpiresAt.Equal(claims.ExpiresAt) {
+				t.Fatalf("VerifyAt() = %+v, want %+v", got, claims)
+			}
+		})
+	}
+}
+
+func TestQRTokenSignerKeyRotation(t *testing.T) {
+	oldSigner := utils.NewQRTokenSigner([]utils.QRKey{oldQRKey}, testQRTTL)
+	token, claims := signQR(t, oldSigner, 0)
+
+	// The new key signs from now on, while codes already shown stay valid
+	rotated := utils.NewQRTokenSigner([]utils.QRKey{newQRKey, oldQRKey}, testQRTTL)
+	if _, err := rotated.VerifyAt(token, claims.IssuedAt); err != nil {
+		t.Fatalf("VerifyAt() of a token signed by the rotated-out key: error = %v, want nil", err)
+	}
+
+	newToken, _ := signQR(t, rotated, 0)
+	if !strings.HasPrefix(newToken, newQRKey.ID+".") {
+		t.Fatalf("Sign() = %q, want it signed by %q", newToken, newQRKey.ID)
+	}
+
+	removed := utils.NewQRTokenSigner([]utils.QRKey{newQRKey}, testQRTTL)
+	if _, err := removed.VerifyAt(token, claims.IssuedAt); !errors.Is(err, utils.ErrQRTokenInvalid) {
+		t.Fatalf("VerifyAt() of a token signed by a removed key: error = %v, want %v", err, utils.ErrQRTokenInvalid)
+	}
+}
+
+func TestQRTokenSignerNonce(t *testing.T) {
+	signer := utils.NewQRTokenSigner([]utils.QRKey{oldQRKey}, time.Hour)
+
+	first, firstClaims := signQR(t, signer, 3)
+	again, _ := signQR(t, signer, 3)
+	if again != first {
+		t.Fatalf("Sign() within the same window = %q, want the same code %q", again, first)
+	}
+
+	// A used-up code must not be issued again
+	_, nextClaims := signQR(t, signer, 4)
+	if nextClaims.Nonce == firstClaims.Nonce {
+		t.Fatalf("Sign() after a code was used has nonce %q, want a new one", nextClaims.Nonce)
+	}
+}
@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+// SnapshotSigner signs attendee snapshots for offline scanners with Ed25519.
+// Devices only need the public key to check a snapshot has not been tampered with.
+type SnapshotSigner struct {
+	Key ed25519.PrivateKey
+}
+
+func NewSnapshotSigner(key ed25519.PrivateKey) *SnapshotSigner {
+	return &SnapshotSigner{Key: key}
+}
+
+// ParseSnapshotKey decodes a base64 encoded 32-byte Ed25519 seed. The key must
+// stay the same across restarts and instances, or devices would reject snapshots.
+func ParseSnapshotKey(raw string) (ed25519.PrivateKey, error) {
+	if raw == "" {
+		return nil, errors.New("no snapshot signing key configured")
+	}
+
+	seed, err := base64.StdEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, fmt.Errorf("malformed snapshot signing key: %w", err)
+	}
+	if len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("snapshot signing key must be %d bytes, got %d", ed25519.SeedSize, len(seed))
+	}
+	return ed25519.NewKeyFromSeed(seed), nil
+}
+
+// Sign returns the base64 encoded signature of payload
+func (s *SnapshotSigner) Sign(payload []byte) string {
+	return base64.StdEncoding.EncodeToString(ed25519.Sign(s.Key, payload))
+}
+
+// PublicKey returns the base64 encoded public key
+func (s *SnapshotSigner) PublicKey() string {
+	return base64.StdEncoding.EncodeToString(s.Key.Public().(ed25519.PublicKey))
+}
+
+// KeyID is a short fingerprint of the public key, so devices can tell when it changes
+func (s *SnapshotSigner) KeyID() string {
+	sum := sha256.Sum256(s.Key.Public().(ed25519.PublicKey))
+	return hex.EncodeToString(sum[:8])
+}
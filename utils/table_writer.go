@@ -0,0 +1,108 @@
+package utils
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// TableWriter writes a spreadsheet one row at a time
+type TableWriter interface {
+	WriteRow(cells []string) error
+	// Close finishes the spreadsheet. Rows may not reach the underlying writer until it is called.
+	Close() error
+}
+
+// utf8BOM marks a CSV file as UTF-8, without which Excel garbles Thai text
+const utf8BOM = "\uFEFF"
+
+type csvTableWriter struct {
+	w *csv.Writer
+}
+
+// NewCSVWriter writes a UTF-8 CSV file, starting with a byte order mark so
+// Excel opens it in the right encoding
+func NewCSVWriter(w io.Writer) (TableWriter, error) {
+	if _, err := io.WriteString(w, utf8BOM); err != nil {
+		return nil, err
+	}
+	return &csvTableWriter{w: csv.NewWriter(w)}, nil
+}
+
+func (t *csvTableWriter) WriteRow(cells []string) error {
+	escaped := make([]string, len(cells))
+	for i, cell := range cells {
+		escaped[i] = escapeFormula(cell)
+	}
+	return t.w.Write(escaped)
+}
+
+func (t *csvTableWriter) Close() error {
+	t.w.Flush()
+	return t.w.Error()
+}
+
+// escapeFormula stops a spreadsheet from running a cell as a formula, since
+// exported values are typed in by attendees
+func escapeFormula(cell string) string {
+	if cell != "" && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+		return "'" + cell
+	}
+	return cell
+}
+
+type xlsxTableWriter struct {
+	w      io.Writer
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	bold   int
+	row    int
+}
+
+// NewXLSXWriter writes an Excel workbook with a single sheet, with the first
+// row in bold. A workbook is a zip archive that excelize only assembles once
+// every row is known, so nothing reaches w until the writer is closed. Rows
+// past the first 16 MB are spooled to a temporary file rather than held in
+// memory. Use NewCSVWriter where rows must be sent as they are written.
+func NewXLSXWriter(w io.Writer) (TableWriter, error) {
+	file := excelize.NewFile()
+	stream, err := file.NewStreamWriter(file.GetSheetName(0))
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	bold, err := file.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &xlsxTableWriter{w: w, file: file, stream: stream, bold: bold}, nil
+}
+
+func (t *xlsxTableWriter) WriteRow(cells []string) error {
+	t.row++
+	cell, err := excelize.CoordinatesToCellName(1, t.row)
+	if err != nil {
+		return err
+	}
+
+	// Cells are written as text, so values such as phone numbers keep their leading zeros
+	values := make([]interface{}, len(cells))
+	for i, value := range cells {
+		values[i] = value
+	}
+	if t.row == 1 {
+		return t.stream.SetRow(cell, values, excelize.RowOpts{StyleID: t.bold})
+	}
+	return t.stream.SetRow(cell, values)
+}
+
+func (t *xlsxTableWriter) Close() error {
+	defer t.file.Close()
+	if err := t.stream.Flush(); err != nil {
+		return err
+	}
+	return t.file.Write(t.w)
+}
@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"sync"
+	"time"
+)
+
+type ttlCacheEntry[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+// TTLCache is a small in-process cache whose entries expire after a fixed TTL
+type TTLCache[K comparable, V any] struct {
+	mu        sync.RWMutex
+	ttl       time.Duration
+	entries   map[K]ttlCacheEntry[V]
+	lastSweep time.Time
+}
+
+func NewTTLCache[K comparable, V any](ttl time.Duration) *TTLCache[K, V] {
+	return &TTLCache[K, V]{ttl: ttl, entries: make(map[K]ttlCacheEntry[V])}
+}
+
+func (c *TTLCache[K, V]) Get(key K) (V, bool) {
+	c.mu.RLock()
+	entry, ok := c.entries[key]
+	c.mu.RUnlock()
+	if !ok || time.Now().After(entry.expiresAt) {
+		var zero V
+		return zero, false
+	}
+	return entry.value, true
+}
+
+func (c *TTLCache[K, V]) Set(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Sweep expired entries at most once per TTL so the map doesn't grow without bound
+	now := time.Now()
+	if now.Sub(c.lastSweep) > c.ttl {
+		for k, e := range c.entries {
+			if now.After(e.expiresAt) {
+				delete(c.entries, k)
+			}
+		}
+		c.lastSweep = now
+	}
+	c.entries[key] = ttlCacheEntry[V]{value: value, expiresAt: now.Add(c.ttl)}
+}
+
+func (c *TTLCache[K, V]) Delete(key K) {
+	c.mu.Lock()
+	delete(c.entries, key)
+	c.mu.Unlock()
+}
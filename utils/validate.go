@@ -0,0 +1,149 @@
+package utils
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/isd-sgcu/cutu2025-backend/domain"
+)
+
+var validate = newValidator()
+
+// embeddedField names embedded structs, whose fields clients send at the top level
+const embeddedField = "<embedded>"
+
+func newValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+
+	// Report fields by the name clients send them as
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		if field.Anonymous && field.Tag.Get("json") == "" {
+			return embeddedField
+		}
+		for _, tag := range []string{"json", "form", "query"} {
+			name := strings.SplitN(field.Tag.Get(tag), ",", 2)[0]
+			if name == "-" {
+				return ""
+			}
+			if name != "" {
+				return name
+			}
+		}
+		return field.Name
+	})
+
+	v.RegisterValidation("thaiphone", func(fl validator.FieldLevel) bool {
+		_, ok := NormalizePhone(fl.Field().String())
+		return ok
+	})
+	v.RegisterValidation("age", func(fl validator.FieldLevel) bool {
+		age, err := strconv.Atoi(strings.TrimSpace(fl.Field().String()))
+		return err == nil && age >= minAge && age <= maxAge
+	})
+	return v
+}
+
+const (
+	minAge = 1
+	maxAge = 120
+)
+
+// Validate checks v against its validate struct tags, returning a
+// *domain.ValidationError that lists every invalid field
+func Validate(v any) error {
+	err := validate.Struct(v)
+	var fieldErrs validator.ValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		return err
+	}
+
+	verr := &domain.ValidationError{}
+	for _, fe := range fieldErrs {
+		rule := fieldRule(fe)
+		verr.Add(fieldPath(fe), rule, fieldMessage(fe, rule))
+	}
+	return verr.Err()
+}
+
+// fieldRule is the name of the rule a field broke. Optional fields that can be
+// cleared are tagged "eq=|rule", meaning blank or rule; they are reported as
+// breaking rule.
+func fieldRule(fe validator.FieldError) string {
+	rule, _, _ := strings.Cut(strings.TrimPrefix(fe.Tag(), "eq=|"), "=")
+	return rule
+}
+
+// fieldPath is the field's path within the request, e.g. scans[0].id
+func fieldPath(fe validator.FieldError) string {
+	segments := strings.Split(fe.Namespace(), ".")[1:]
+	path := segments[:0]
+	for _, segment := range segments {
+		if segment != embeddedField {
+			path = append(path, segment)
+		}
+	}
+	return strings.Join(path, ".")
+}
+
+func fieldMessage(fe validator.FieldError, rule string) string {
+	param := fe.Param()
+	isString := fe.Kind() == reflect.String
+	switch rule {
+	case "required":
+		return "is required"
+	case "required_if":
+		if field, value, ok := strings.Cut(param, " "); ok {
+			return "is required when " + lowerFirst(field) + " is " + value
+		}
+		return "is required"
+	case "oneof":
+		return "must be one of " + strings.Join(strings.Fields(param), ", ")
+	case "email":
+		return "must be a valid email address"
+	case "thaiphone":
+		return "must be a Thai phone number, e.g. 0812345678 or +66812345678"
+	case "age":
+		return "must be a whole number from " + strconv.Itoa(minAge) + " to " + strconv.Itoa(maxAge)
+	case "timezone":
+		return "must be an IANA time zone, e.g. Asia/Bangkok"
+	case "gtfield":
+		return "must be after " + lowerFirst(param)
+	case "number", "numeric":
+		return "must be a number"
+	case "len":
+		if isString {
+			return "must be " + param + " characters long"
+		}
+		return "must have " + param + " items"
+	case "min", "gte":
+		if isString && param == "1" {
+			return "must not be empty"
+		}
+		if isString {
+			return "must be at least " + param + " characters long"
+		}
+		if fe.Kind() == reflect.Slice {
+			return "must have at least " + param + " items"
+		}
+		return "must be at least " + param
+	case "max", "lte":
+		if isString {
+			return "must be at most " + param + " characters long"
+		}
+		if fe.Kind() == reflect.Slice {
+			return "must have at most " + param + " items"
+		}
+		return "must be at most " + param
+	}
+	return "is invalid"
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}